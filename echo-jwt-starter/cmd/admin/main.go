@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"echo-jwt-starter/config"
+	"echo-jwt-starter/internal/repository/psql"
+	"echo-jwt-starter/internal/service"
+	dbconfig "echo-jwt-starter/pkg/db"
+	"echo-jwt-starter/pkg/errmsg"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+const usage = `Usage: admin [-config_path=.] [-config_filename=.env] <command> [flags]
+
+Bootstraps admin accounts directly in the database, further admins are managed with /api/admin/users.
+
+Commands:
+  create -email <email> [-password <password>]
+                           create a verified admin account, the password can also be given in
+                           ADMIN_PASSWORD so it stays out of the shell history; an existing
+                           account with the email is promoted to admin instead
+`
+
+func main() {
+	config.LoadEnvs()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("create", flag.ExitOnError)
+		email := fs.String("email", "", "email of the admin")
+		password := fs.String("password", os.Getenv("ADMIN_PASSWORD"), "password of the admin (default ADMIN_PASSWORD)")
+		_ = fs.Parse(args[1:])
+		if *email == "" {
+			exit(fmt.Errorf("-email is required"))
+		}
+
+		db, err := dbconfig.NewPostgresConnection()
+		if err != nil {
+			exit(err)
+		}
+		defer db.Close()
+
+		authService := service.NewAuthService(psql.NewRepositoryRegistry(db))
+		user, created, err := authService.BootstrapAdmin(context.Background(), strings.TrimSpace(*email), *password)
+		if err != nil {
+			exit(err)
+		}
+		if created {
+			fmt.Printf("created admin %s (%s)\n", user.Email, user.ID)
+		} else {
+			fmt.Printf("promoted existing user %s (%s) to admin\n", user.Email, user.ID)
+		}
+
+	default:
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintf(os.Stderr, "admin: %v\n", err)
+	var customErr *errmsg.CustomError
+	if errors.As(err, &customErr) {
+		for field, messages := range customErr.Errors {
+			for _, message := range messages {
+				fmt.Fprintf(os.Stderr, "  %s: %s\n", field, message)
+			}
+		}
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"echo-jwt-starter/config"
+	"echo-jwt-starter/pkg/jwthandler"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+const usage = `Usage: keyring [-config_path=.] [-config_filename=.env] <command> [flags]
+
+Manages the JWT signing keys stored in JWT_KEY_DIR.
+
+Commands:
+  list                     show the keys in the manifest
+  generate -alg RS256      create a new key (HS256, RS256, ES256 or EdDSA), not used for signing until promoted
+  promote -kid <kid>       make <kid> the signing key, the old signing key keeps verifying tokens
+  retire                   remove keys demoted longer than JWT_REFRESH_TTL_DAYS ago
+`
+
+func main() {
+	config.LoadEnvs()
+
+	dir := config.Envs.Guard.JwtKeyDir
+	if dir == "" {
+		exit(fmt.Errorf("JWT_KEY_DIR is not set"))
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+
+	now := time.Now()
+	switch args[0] {
+	case "list":
+		m, err := jwthandler.ReadManifest(dir)
+		if err != nil {
+			exit(err)
+		}
+		for _, key := range m.Keys {
+			status := "verify"
+			if key.Kid == m.Current {
+				status = "current"
+			}
+			demotedAt := "-"
+			if key.DemotedAt != nil {
+				demotedAt = key.DemotedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%-18s %-6s %-8s created=%s demoted=%s\n", key.Kid, key.Alg, status, key.CreatedAt.Format(time.RFC3339), demotedAt)
+		}
+
+	case "generate":
+		fs := flag.NewFlagSet("generate", flag.ExitOnError)
+		alg := fs.String("alg", config.Envs.Guard.JwtAlgorithm, "signing algorithm (HS256, RS256, ES256, EdDSA)")
+		_ = fs.Parse(args[1:])
+
+		key, err := jwthandler.GenerateKeyFile(dir, *alg, now)
+		if err != nil {
+			exit(err)
+		}
+		fmt.Printf("generated %s key %s (%s)\n", key.Alg, key.Kid, key.File)
+
+	case "promote":
+		fs := flag.NewFlagSet("promote", flag.ExitOnError)
+		kid := fs.String("kid", "", "key id to promote")
+		_ = fs.Parse(args[1:])
+		if *kid == "" {
+			exit(fmt.Errorf("-kid is required"))
+		}
+
+		if err := jwthandler.PromoteKey(dir, *kid, now); err != nil {
+			exit(err)
+		}
+		fmt.Printf("promoted %s to signing key\n", *kid)
+
+	case "retire":
+		grace := time.Duration(config.Envs.Guard.JwtRefreshTtlDays) * 24 * time.Hour
+		retired, err := jwthandler.RetireKeys(dir, grace, now)
+		if err != nil {
+			exit(err)
+		}
+		if len(retired) == 0 {
+			fmt.Println("no keys to retire")
+			return
+		}
+		for _, kid := range retired {
+			fmt.Printf("retired %s\n", kid)
+		}
+
+	default:
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintf(os.Stderr, "keyring: %v\n", err)
+	os.Exit(1)
+}
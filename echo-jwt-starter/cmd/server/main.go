@@ -4,8 +4,15 @@ import (
 	"echo-jwt-starter/config"
 	"echo-jwt-starter/internal/repository/psql"
 	"echo-jwt-starter/internal/routes"
+	"echo-jwt-starter/pkg/authcookie"
+	"echo-jwt-starter/pkg/authz"
+	"echo-jwt-starter/pkg/clientip"
 	dbconfig "echo-jwt-starter/pkg/db"
+	"echo-jwt-starter/pkg/jwthandler"
 	"echo-jwt-starter/pkg/logging"
+	"echo-jwt-starter/pkg/password"
+	"echo-jwt-starter/pkg/rbac"
+	"echo-jwt-starter/pkg/revocation"
 	echovalidator "echo-jwt-starter/pkg/validator"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog"
@@ -33,6 +40,12 @@ func main() {
 	}
 	logging.SetupLogger(config.Envs.App.Environment, config.Envs.App.LogFile, logLevel)
 
+	// Load JWT signing key
+	if err = jwthandler.Setup(); err != nil {
+		log.Fatal().Err(err).Msg("main:: failed to load JWT signing key")
+	}
+	jwthandler.WatchKeyDir(time.Minute)
+
 	// Init DB
 	db, err := dbconfig.NewPostgresConnection()
 	if err != nil {
@@ -42,6 +55,11 @@ func main() {
 
 	// Echo instance
 	e := echo.New()
+	// Client IP for rate limits and the login guard, forwarded headers are only trusted from known proxies
+	e.IPExtractor, err = clientip.Extractor(config.Envs.App.TrustedProxies)
+	if err != nil {
+		log.Fatal().Err(err).Msg("main:: invalid APP_TRUSTED_PROXIES")
+	}
 	// Application Middlewares
 	if !config.Envs.App.Environment.IsProd() {
 		//app.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(50)))
@@ -62,15 +80,22 @@ func main() {
 			},
 		}))
 	}
-	e.Use(middleware.CORS())
-	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+	corsConfig := middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
 		//AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
 		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD"},
 		//AllowHeaders: "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization,aplication/json; charset=utf-8,x-api-key",
-		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Content-Length", "Accept-Language", "Accept-Encoding", "Connection", "Access-Control-Allow-Origin", "Authorization", "aplication/json; charset=utf-8", "x-api-key"},
+		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Content-Length", "Accept-Language", "Accept-Encoding", "Connection", "Access-Control-Allow-Origin", "Authorization", "aplication/json; charset=utf-8", "x-api-key", config.Envs.Cookie.CsrfHeader},
 		//AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderContentLength, echo.HeaderAcceptLanguage, echo.HeaderAcceptEncoding, echo.HeaderConnection, echo.HeaderAccessControlAllowOrigin, echo.HeaderAuthorization},
-	}))
+	}
+	// Cookie mode: browsers only send credentials to explicitly allowed frontend origins
+	if origins := authcookie.AllowedOrigins(); authcookie.Enabled() && len(origins) > 0 {
+		corsConfig.AllowOrigins = origins
+		corsConfig.AllowCredentials = true
+	} else {
+		e.Use(middleware.CORS())
+	}
+	e.Use(middleware.CORSWithConfig(corsConfig))
 	e.Use(middleware.Gzip())
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
 		LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
@@ -92,10 +117,34 @@ func main() {
 	e.Use(middleware.Secure())
 	e.Validator = echovalidator.NewValidator() // Set custom validator
 
+	repoRegistry := psql.NewRepositoryRegistry(db)
+
+	// Token revocation store (default: in-memory)
+	if config.Envs.Guard.RevocationStore == "postgres" {
+		revocation.SetStore(repoRegistry.GetRevokedTokenRepository())
+	}
+
+	// Permission resolver for middleware.RequirePermission (user_roles -> role_permissions)
+	rbac.SetResolver(rbac.NewCache(repoRegistry.GetRoleRepository(), time.Duration(config.Envs.Auth.PermissionCacheSeconds)*time.Second))
+
+	// Attribute/ownership policy for authz.Authorize
+	policy, err := authz.Load(config.Envs.Authz.PolicyFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("main:: failed to load authorization policy")
+	}
+	authz.SetPolicy(policy)
+
+	// Leaked password check for password.IsBreached, loaded once and required when configured
+	if file := config.Envs.Password.BreachedFile; file != "" {
+		breached, err := password.LoadBreached(file)
+		if err != nil {
+			log.Fatal().Err(err).Msg("main:: failed to load PASSWORD_BREACHED_FILE")
+		}
+		password.SetBreached(breached)
+	}
+
 	// Route registry
-	routeRegistry := routes.NewRouteRegistry(
-		psql.NewRepositoryRegistry(db),
-	)
+	routeRegistry := routes.NewRouteRegistry(repoRegistry)
 	routeRegistry.RegisterRoutes(e)
 
 	// Start server
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type AdminUserListRequest struct {
+	Search  string `query:"search" validate:"omitempty,max=100"`
+	Role    string `query:"role" validate:"omitempty,max=50"`
+	Status  string `query:"status" validate:"omitempty,oneof=active disabled deleted"`
+	Page    int    `query:"page" validate:"omitempty,min=1"`
+	PerPage int    `query:"per_page" validate:"omitempty,min=1,max=100"`
+}
+
+type AdminUserResponse struct {
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	Name            string     `json:"name"`
+	AvatarURL       string     `json:"avatar_url"`
+	Role            string     `json:"role"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	LockedUntil     *time.Time `json:"locked_until"`
+	DisabledAt      *time.Time `json:"disabled_at"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type AdminUserListResponse struct {
+	Users   []AdminUserResponse `json:"users"`
+	Page    int                 `json:"page"`
+	PerPage int                 `json:"per_page"`
+	Total   int                 `json:"total"`
+}
+
+// AdminUserRoleRequest replaces the primary role of the user (users.role, carried in the JWT).
+type AdminUserRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
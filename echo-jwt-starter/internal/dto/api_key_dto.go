@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type ApiKeyCreateRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Owner     string     `json:"owner" validate:"omitempty,max=100"`
+	Scopes    []string   `json:"scopes" validate:"omitempty,dive,required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ApiKeyUpdateRequest only changes the fields that are present in the body.
+type ApiKeyUpdateRequest struct {
+	Name      *string    `json:"name" validate:"omitempty,min=1,max=100"`
+	Owner     *string    `json:"owner" validate:"omitempty,max=100"`
+	Scopes    *[]string  `json:"scopes" validate:"omitempty,dive,required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ApiKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Owner      string     `json:"owner"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ApiKeySecretResponse carries the plain key, it is only returned on create and rotate.
+type ApiKeySecretResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
@@ -1,18 +1,34 @@
 package dto
 
+import "time"
+
+// ClientInfo describes the device a request comes from, it is filled by the handler.
+type ClientInfo struct {
+	IP        string `json:"-"` // used for brute-force tracking and the session list
+	UserAgent string `json:"-"`
+}
+
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"` // the policy applies to new passwords only
+	Scope    string `json:"scope"`                        // space separated, limits the issued tokens to these scopes, unrestricted when empty
+	ClientInfo
 }
 
 type LoginResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MfaRequired  bool   `json:"mfa_required,omitempty"` // set when the login must be completed at /auth/mfa/verify
+	MfaToken     string `json:"mfa_token,omitempty"`
+}
+
+type RefreshRequest struct {
+	Scope string `json:"scope"` // space separated subset of the refresh token scope, kept as is when empty
 }
 
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email,email_blacklist"`
-	Password string `json:"password" validate:"required,strong_password"`
+	Password string `json:"password" validate:"required"` // checked against the password policy by the service
 }
 
 type RegisterResponse struct {
@@ -20,3 +36,97 @@ type RegisterResponse struct {
 	Email string `json:"email"`
 	Role  string `json:"role"`
 }
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token                string `json:"token" validate:"required"`
+	Password             string `json:"password" validate:"required"` // checked against the password policy by the service
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,eqfield=Password"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type MagicLinkRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type MagicLinkVerifyRequest struct {
+	Token string `json:"token" validate:"required"`
+	ClientInfo
+}
+
+type MfaEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+	QRCode     string `json:"qr_code"` // data:image/png;base64,...
+}
+
+type MfaCodeRequest struct {
+	Code string `json:"code" validate:"required"` // TOTP code, or a recovery code where accepted
+}
+
+type MfaVerifyRequest struct {
+	MfaToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"` // TOTP code or one of the recovery codes
+	ClientInfo
+}
+
+type MfaRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type OidcAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+type OidcCallbackRequest struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+	ClientInfo
+}
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"` // the session of the access token used for the request
+}
+
+type RevokedSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+type ProfileResponse struct {
+	ID              string     `json:"id"`
+	Email           string     `json:"email"`
+	Name            string     `json:"name"`
+	AvatarURL       string     `json:"avatar_url"`
+	Role            string     `json:"role"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	MfaEnabled      bool       `json:"mfa_enabled"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// UpdateProfileRequest only changes the fields that are sent, an empty string clears the field.
+type UpdateProfileRequest struct {
+	Name      *string `json:"name" validate:"omitempty,max=100"`
+	AvatarURL *string `json:"avatar_url" validate:"omitempty,url,max=2048"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword      string `json:"current_password" validate:"required"`
+	Password             string `json:"password" validate:"required"` // checked against the password policy by the service
+	PasswordConfirmation string `json:"password_confirmation" validate:"required,eqfield=Password"`
+}
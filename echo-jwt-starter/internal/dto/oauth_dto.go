@@ -0,0 +1,47 @@
+package dto
+
+// IntrospectRequest is the form body of /oauth/introspect (RFC 7662).
+type IntrospectRequest struct {
+	Token         string `json:"token" form:"token" validate:"required"`
+	TokenTypeHint string `json:"token_type_hint" form:"token_type_hint"` // ignored, the type is read from the token
+}
+
+// IntrospectResponse is the standard introspection response, only Active is set for inactive tokens.
+type IntrospectResponse struct {
+	Active    bool   `json:"active"`
+	Sub       string `json:"sub,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Iss       string `json:"iss,omitempty"`
+	Jti       string `json:"jti,omitempty"`
+	Scope     string `json:"scope,omitempty"` // space separated
+	Role      string `json:"role,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	TokenType string `json:"token_type,omitempty"` // access_token | refresh_token | client_token
+}
+
+// RevokeTokenRequest is the form body of /oauth/revoke (RFC 7009).
+type RevokeTokenRequest struct {
+	Token         string `json:"token" form:"token" validate:"required"`
+	TokenTypeHint string `json:"token_type_hint" form:"token_type_hint"` // ignored, the type is read from the token
+}
+
+// OAuthErrorResponse is the error body defined by RFC 6749 section 5.2.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// TokenRequest is the form body of /oauth/token, only grant_type=client_credentials is supported.
+type TokenRequest struct {
+	GrantType string `json:"grant_type" form:"grant_type" validate:"required"`
+	Scope     string `json:"scope" form:"scope"` // space separated subset of the client scopes, all of them when empty
+}
+
+// TokenResponse is the successful token response of RFC 6749 section 5.1.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope,omitempty"`
+}
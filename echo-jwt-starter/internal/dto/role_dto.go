@@ -0,0 +1,35 @@
+package dto
+
+type RoleResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type PermissionResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleCreateRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=50"`
+	Description string   `json:"description" validate:"omitempty,max=200"`
+	Permissions []string `json:"permissions" validate:"omitempty,dive,required"`
+}
+
+// RolePermissionsRequest replaces every permission of the role.
+type RolePermissionsRequest struct {
+	Permissions []string `json:"permissions" validate:"omitempty,dive,required"`
+}
+
+type UserRoleGrantRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type UserRolesResponse struct {
+	UserID      string   `json:"user_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// PasswordHistoryDB is a hash of a password the user has set, kept to prevent reuse.
+type PasswordHistoryDB struct {
+	Id           string    `json:"id"`
+	UserId       string    `json:"user_id"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
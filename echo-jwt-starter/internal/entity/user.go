@@ -1,8 +1,28 @@
 package entity
 
+import "time"
+
 type UserDB struct {
-	Id       string `json:"id"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Role     string `json:"role"`
+	Id               string     `json:"id"`
+	Email            string     `json:"email"`
+	Name             string     `json:"name"`
+	AvatarURL        string     `json:"avatar_url"`
+	Password         string     `json:"password"`
+	Role             string     `json:"role"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at"`
+	FailedLoginCount int        `json:"failed_login_count"`
+	LockedUntil      *time.Time `json:"locked_until"`
+	DisabledAt       *time.Time `json:"disabled_at"`
+	DeletedAt        *time.Time `json:"deleted_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// UserFilter narrows the admin user listing. Status is "active", "disabled" or "deleted", the
+// default lists every account that is not deleted.
+type UserFilter struct {
+	Search string
+	Role   string
+	Status string
+	Limit  int
+	Offset int
 }
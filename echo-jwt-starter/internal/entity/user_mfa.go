@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+type UserMfaDB struct {
+	UserId       string     `json:"user_id"`
+	Secret       string     `json:"-"`
+	EnabledAt    *time.Time `json:"enabled_at"`
+	LastUsedStep *int64     `json:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
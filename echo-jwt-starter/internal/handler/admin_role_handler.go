@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/internal/service"
+	"echo-jwt-starter/middleware"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type AdminRoleHandler struct {
+	Service service.AdminRoleService
+}
+
+func NewAdminRoleHandler(service service.AdminRoleService) *AdminRoleHandler {
+	return &AdminRoleHandler{Service: service}
+}
+
+func (h *AdminRoleHandler) ListRoles(c echo.Context) error {
+	res, err := h.Service.ListRoles(c.Request().Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::AdminRole.ListRoles - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Role berhasil dimuat"))
+}
+
+func (h *AdminRoleHandler) CreateRole(c echo.Context) error {
+	var req dto.RoleCreateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.CreateRole - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.CreateRole - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.CreateRole(c.Request().Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::AdminRole.CreateRole - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusCreated, response.Success(res, "Role berhasil dibuat"))
+}
+
+func (h *AdminRoleHandler) SetRolePermissions(c echo.Context) error {
+	name := c.Param("name")
+
+	var req dto.RolePermissionsRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.SetRolePermissions - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.SetRolePermissions - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.SetRolePermissions(c.Request().Context(), name, req)
+	if err != nil {
+		log.Warn().Err(err).Str("role", name).Msg("handler::AdminRole.SetRolePermissions - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Permission role berhasil diperbarui"))
+}
+
+func (h *AdminRoleHandler) ListPermissions(c echo.Context) error {
+	res, err := h.Service.ListPermissions(c.Request().Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::AdminRole.ListPermissions - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Permission berhasil dimuat"))
+}
+
+func (h *AdminRoleHandler) UserRoles(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.UserRoles(c.Request().Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminRole.UserRoles - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Role user berhasil dimuat"))
+}
+
+func (h *AdminRoleHandler) GrantRole(c echo.Context) error {
+	id := c.Param("id")
+
+	var req dto.UserRoleGrantRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.GrantRole - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.GrantRole - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.GrantRole(c.Request().Context(), id, req, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminRole.GrantRole - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Role berhasil diberikan"))
+}
+
+func (h *AdminRoleHandler) RevokeRole(c echo.Context) error {
+	id := c.Param("id")
+	role := c.Param("role")
+
+	res, err := h.Service.RevokeRole(c.Request().Context(), id, role, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Str("role", role).Msg("handler::AdminRole.RevokeRole - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Role berhasil dicabut"))
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/internal/service"
+	"echo-jwt-starter/middleware"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type AdminUserHandler struct {
+	Service service.AdminUserService
+}
+
+func NewAdminUserHandler(service service.AdminUserService) *AdminUserHandler {
+	return &AdminUserHandler{Service: service}
+}
+
+func (h *AdminUserHandler) List(c echo.Context) error {
+	var req dto.AdminUserListRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminUser.List - Failed to bind query")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminUser.List - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.List(c.Request().Context(), req, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::AdminUser.List - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "User berhasil dimuat"))
+}
+
+func (h *AdminUserHandler) Get(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.Get(c.Request().Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Get - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "User berhasil dimuat"))
+}
+
+func (h *AdminUserHandler) UpdateRole(c echo.Context) error {
+	id := c.Param("id")
+
+	var req dto.AdminUserRoleRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminUser.UpdateRole - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminUser.UpdateRole - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.UpdateRole(c.Request().Context(), id, req, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.UpdateRole - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Role user berhasil diubah"))
+}
+
+func (h *AdminUserHandler) Disable(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.Disable(c.Request().Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Disable - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Akun berhasil dinonaktifkan"))
+}
+
+func (h *AdminUserHandler) Enable(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.Enable(c.Request().Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Enable - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Akun berhasil diaktifkan"))
+}
+
+func (h *AdminUserHandler) Delete(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := h.Service.Delete(c.Request().Context(), id, middleware.GetSubjectFromContext(c)); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Delete - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Akun berhasil dihapus"))
+}
+
+func (h *AdminUserHandler) Restore(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.Restore(c.Request().Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Restore - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Akun berhasil dipulihkan"))
+}
+
+func (h *AdminUserHandler) Unlock(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := h.Service.Unlock(c.Request().Context(), id, middleware.GetSubjectFromContext(c)); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Unlock - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Akun berhasil dibuka"))
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/internal/service"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type ApiKeyHandler struct {
+	Service service.ApiKeyService
+}
+
+func NewApiKeyHandler(service service.ApiKeyService) *ApiKeyHandler {
+	return &ApiKeyHandler{Service: service}
+}
+
+func (h *ApiKeyHandler) List(c echo.Context) error {
+	res, err := h.Service.List(c.Request().Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ApiKey.List - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "API key berhasil dimuat"))
+}
+
+func (h *ApiKeyHandler) Get(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.Get(c.Request().Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::ApiKey.Get - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "API key berhasil dimuat"))
+}
+
+func (h *ApiKeyHandler) Create(c echo.Context) error {
+	var req dto.ApiKeyCreateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ApiKey.Create - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ApiKey.Create - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.Create(c.Request().Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ApiKey.Create - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusCreated, response.Success(res, "API key berhasil dibuat, simpan key ini karena tidak akan ditampilkan lagi"))
+}
+
+func (h *ApiKeyHandler) Update(c echo.Context) error {
+	id := c.Param("id")
+
+	var req dto.ApiKeyUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ApiKey.Update - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ApiKey.Update - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.Update(c.Request().Context(), id, req)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::ApiKey.Update - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "API key berhasil diperbarui"))
+}
+
+func (h *ApiKeyHandler) Rotate(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.Rotate(c.Request().Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::ApiKey.Rotate - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "API key berhasil dirotasi, simpan key ini karena tidak akan ditampilkan lagi"))
+}
+
+func (h *ApiKeyHandler) Revoke(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := h.Service.Revoke(c.Request().Context(), id); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::ApiKey.Revoke - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "API key berhasil dicabut"))
+}
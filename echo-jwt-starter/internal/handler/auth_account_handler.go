@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/middleware"
+	"echo-jwt-starter/pkg/authcookie"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) Profile(c echo.Context) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
+	}
+
+	res, err := h.Service.Profile(c.Request().Context(), claims)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::Profile - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Profile loaded"))
+}
+
+func (h *AuthHandler) UpdateProfile(c echo.Context) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::UpdateProfile - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::UpdateProfile - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.UpdateProfile(c.Request().Context(), claims, req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateProfile - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Profil berhasil diperbarui"))
+}
+
+func (h *AuthHandler) ChangePassword(c echo.Context) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ChangePassword - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ChangePassword - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if err := h.Service.ChangePassword(c.Request().Context(), claims, req); err != nil {
+		log.Warn().Err(err).Msg("handler::ChangePassword - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Password berhasil diubah, sesi di perangkat lain telah diakhiri"))
+}
+
+func (h *AuthHandler) DeleteAccount(c echo.Context) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
+	}
+
+	if err := h.Service.DeleteAccount(c.Request().Context(), claims); err != nil {
+		log.Warn().Err(err).Msg("handler::DeleteAccount - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if authcookie.Enabled() {
+		for _, cookie := range authcookie.ClearCookies() {
+			c.SetCookie(cookie)
+		}
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Akun berhasil dihapus"))
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"echo-jwt-starter/config"
 	"echo-jwt-starter/internal/dto"
 	"echo-jwt-starter/internal/service"
 	"echo-jwt-starter/middleware"
+	"echo-jwt-starter/pkg/authcookie"
 	"echo-jwt-starter/pkg/errmsg"
 	"echo-jwt-starter/pkg/response"
 	"net/http"
@@ -36,6 +38,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return c.JSON(code, response.Error(errs))
 	}
 
+	req.ClientInfo = clientInfo(c)
 	res, err := h.Service.Login(c.Request().Context(), req)
 	if err != nil {
 		log.Warn().Err(err).Msg("handler::Login - Service returned error")
@@ -43,23 +46,40 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return c.JSON(code, response.Error(errs))
 	}
 
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
 	return c.JSON(http.StatusOK, response.Success(res, "Login berhasil"))
 }
 
 func (h *AuthHandler) Refresh(c echo.Context) error {
-	authHeader := c.Request().Header.Get("Authorization")
-	if !strings.HasPrefix(authHeader, "Bearer ") {
+	tokenStr := refreshToken(c)
+	if tokenStr == "" {
 		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
 	}
 
-	tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-	res, err := h.Service.RefreshToken(c.Request().Context(), tokenStr)
+	// the body is optional, it only carries a narrower scope
+	var req dto.RefreshRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::Refresh - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.RefreshToken(c.Request().Context(), tokenStr, req)
 	if err != nil {
 		log.Warn().Err(err).Msg("handler::Refresh - Service returned error")
 		code, errs := errmsg.Errors(err, &tokenStr)
 		return c.JSON(code, response.Error(errs))
 	}
 
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
 	return c.JSON(http.StatusOK, response.Success(res, "Access token refreshed"))
 }
 
@@ -86,13 +106,148 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	return c.JSON(http.StatusCreated, response.Success(res, "Registrasi berhasil"))
 }
 
-func (h *AuthHandler) Profile(c echo.Context) error {
-	userId := middleware.GetUserIDFromContext(c)
-	role := middleware.GetRoleFromContext(c)
+func (h *AuthHandler) Logout(c echo.Context) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
+	}
+
+	if err := h.Service.Logout(c.Request().Context(), claims); err != nil {
+		log.Warn().Err(err).Msg("handler::Logout - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if authcookie.Enabled() {
+		for _, cookie := range authcookie.ClearCookies() {
+			c.SetCookie(cookie)
+		}
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Logout berhasil"))
+}
+
+func (h *AuthHandler) ForgotPassword(c echo.Context) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ForgotPassword - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ForgotPassword - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if err := h.Service.ForgotPassword(c.Request().Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::ForgotPassword - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Jika email terdaftar, link reset password telah dikirim"))
+}
+
+func (h *AuthHandler) ResetPassword(c echo.Context) error {
+	var req dto.ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ResetPassword - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ResetPassword - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
 
-	data := map[string]string{
-		"user_id": userId,
-		"role":    role,
+	if err := h.Service.ResetPassword(c.Request().Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::ResetPassword - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
 	}
-	return c.JSON(http.StatusOK, response.Success(data, "Profile loaded"))
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Password berhasil direset, silakan login kembali"))
+}
+
+func (h *AuthHandler) VerifyEmail(c echo.Context) error {
+	var req dto.VerifyEmailRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyEmail - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyEmail - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if err := h.Service.VerifyEmail(c.Request().Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::VerifyEmail - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Email berhasil diverifikasi"))
+}
+
+func (h *AuthHandler) ResendVerification(c echo.Context) error {
+	var req dto.ResendVerificationRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ResendVerification - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ResendVerification - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if err := h.Service.ResendVerification(c.Request().Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::ResendVerification - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Jika email terdaftar dan belum terverifikasi, link verifikasi telah dikirim"))
+}
+
+// refreshToken returns the refresh token of the Authorization header, falling back to the refresh cookie in cookie mode.
+func refreshToken(c echo.Context) string {
+	if authHeader := c.Request().Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	if authcookie.Enabled() {
+		if cookie, err := c.Cookie(config.Envs.Cookie.RefreshName); err == nil {
+			return cookie.Value
+		}
+	}
+	return ""
+}
+
+// tokenResponse sets the token cookies in cookie mode and removes the tokens from the response body,
+// so browser clients never expose them to JavaScript.
+func tokenResponse(c echo.Context, res dto.LoginResponse) (dto.LoginResponse, error) {
+	if !authcookie.Enabled() || res.AccessToken == "" {
+		return res, nil
+	}
+
+	cookies, err := authcookie.TokenCookies(res.AccessToken, res.RefreshToken)
+	if err != nil {
+		log.Error().Err(err).Msg("handler::tokenResponse - Failed to create session cookies")
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(http.StatusInternalServerError, errmsg.WithMessage("Gagal membuat cookie sesi"))
+	}
+	for _, cookie := range cookies {
+		c.SetCookie(cookie)
+	}
+
+	return dto.LoginResponse{MfaRequired: res.MfaRequired, MfaToken: res.MfaToken}, nil
+}
+
+// clientInfo returns the IP and User-Agent of the device making the request.
+func clientInfo(c echo.Context) dto.ClientInfo {
+	return dto.ClientInfo{IP: c.RealIP(), UserAgent: c.Request().UserAgent()}
 }
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) RequestMagicLink(c echo.Context) error {
+	var req dto.MagicLinkRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::RequestMagicLink - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::RequestMagicLink - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if err := h.Service.RequestMagicLink(c.Request().Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::RequestMagicLink - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Jika email terdaftar, link login telah dikirim"))
+}
+
+func (h *AuthHandler) VerifyMagicLink(c echo.Context) error {
+	var req dto.MagicLinkVerifyRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyMagicLink - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyMagicLink - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	req.ClientInfo = clientInfo(c)
+	res, err := h.Service.VerifyMagicLink(c.Request().Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::VerifyMagicLink - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Login berhasil"))
+}
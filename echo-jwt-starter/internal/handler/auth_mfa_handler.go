@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/middleware"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) EnrollMfa(c echo.Context) error {
+	userId := middleware.GetUserIDFromContext(c)
+
+	res, err := h.Service.EnrollMfa(c.Request().Context(), userId)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::EnrollMfa - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Scan QR code lalu konfirmasi dengan kode dari aplikasi authenticator"))
+}
+
+func (h *AuthHandler) ConfirmMfa(c echo.Context) error {
+	var req dto.MfaCodeRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ConfirmMfa - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ConfirmMfa - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.ConfirmMfa(c.Request().Context(), middleware.GetUserIDFromContext(c), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ConfirmMfa - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "MFA aktif, simpan recovery code di tempat yang aman"))
+}
+
+func (h *AuthHandler) VerifyMfa(c echo.Context) error {
+	var req dto.MfaVerifyRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyMfa - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyMfa - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	req.ClientInfo = clientInfo(c)
+	res, err := h.Service.VerifyMfa(c.Request().Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::VerifyMfa - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Login berhasil"))
+}
+
+func (h *AuthHandler) DisableMfa(c echo.Context) error {
+	var req dto.MfaCodeRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::DisableMfa - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::DisableMfa - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if err := h.Service.DisableMfa(c.Request().Context(), middleware.GetUserIDFromContext(c), req); err != nil {
+		log.Warn().Err(err).Msg("handler::DisableMfa - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "MFA dinonaktifkan"))
+}
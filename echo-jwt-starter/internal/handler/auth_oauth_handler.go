@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/pkg/errmsg"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// Introspect answers RFC 7662 token introspection, the response is not wrapped in response.Success.
+func (h *AuthHandler) Introspect(c echo.Context) error {
+	var req dto.IntrospectRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::Introspect - Failed to bind request body")
+		return c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: "invalid_request"})
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::Introspect - Validation failed")
+		return c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "token is required"})
+	}
+
+	res, err := h.Service.Introspect(c.Request().Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::Introspect - Service returned error")
+		code, _ := errmsg.Errors[any](err)
+		return c.JSON(code, dto.OAuthErrorResponse{Error: "server_error"})
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, res)
+}
+
+// RevokeToken answers RFC 7009 token revocation with an empty 200, also for unknown tokens.
+func (h *AuthHandler) RevokeToken(c echo.Context) error {
+	var req dto.RevokeTokenRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::RevokeToken - Failed to bind request body")
+		return c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: "invalid_request"})
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::RevokeToken - Validation failed")
+		return c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "token is required"})
+	}
+
+	if err := h.Service.RevokeToken(c.Request().Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::RevokeToken - Service returned error")
+		code, _ := errmsg.Errors[any](err)
+		return c.JSON(code, dto.OAuthErrorResponse{Error: "server_error"})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) OidcProviders(c echo.Context) error {
+	return c.JSON(http.StatusOK, response.Success(h.Service.OidcProviders(), "Daftar provider OIDC"))
+}
+
+func (h *AuthHandler) OidcAuthorize(c echo.Context) error {
+	provider := c.Param("provider")
+
+	res, err := h.Service.OidcAuthorize(c.Request().Context(), provider)
+	if err != nil {
+		log.Warn().Err(err).Str("provider", provider).Msg("handler::OidcAuthorize - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Arahkan user ke authorization_url"))
+}
+
+func (h *AuthHandler) OidcCallback(c echo.Context) error {
+	provider := c.Param("provider")
+
+	var req dto.OidcCallbackRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OidcCallback - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OidcCallback - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	req.ClientInfo = clientInfo(c)
+	res, err := h.Service.OidcCallback(c.Request().Context(), provider, req)
+	if err != nil {
+		log.Warn().Err(err).Str("provider", provider).Msg("handler::OidcCallback - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Login berhasil"))
+}
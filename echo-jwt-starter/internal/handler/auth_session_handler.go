@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"echo-jwt-starter/middleware"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) ListSessions(c echo.Context) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
+	}
+
+	res, err := h.Service.ListSessions(c.Request().Context(), claims)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ListSessions - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Sesi berhasil dimuat"))
+}
+
+func (h *AuthHandler) RevokeSession(c echo.Context) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
+	}
+
+	id := c.Param("id")
+	if err := h.Service.RevokeSession(c.Request().Context(), claims, id); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::RevokeSession - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Sesi berhasil diakhiri"))
+}
+
+func (h *AuthHandler) RevokeOtherSessions(c echo.Context) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized"))
+	}
+
+	res, err := h.Service.RevokeOtherSessions(c.Request().Context(), claims)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::RevokeOtherSessions - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Semua sesi lain berhasil diakhiri"))
+}
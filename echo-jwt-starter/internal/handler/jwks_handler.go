@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"echo-jwt-starter/pkg/jwthandler"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type JWKSHandler struct{}
+
+func NewJWKSHandler() *JWKSHandler {
+	return &JWKSHandler{}
+}
+
+// GetJWKS serves the public verification keys as a standard JWK Set (not wrapped in response.Success).
+func (h *JWKSHandler) GetJWKS(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, jwthandler.JWKS())
+}
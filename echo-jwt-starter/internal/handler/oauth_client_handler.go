@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/internal/service"
+	"echo-jwt-starter/middleware"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type OAuthClientHandler struct {
+	Service service.OAuthClientService
+}
+
+func NewOAuthClientHandler(service service.OAuthClientService) *OAuthClientHandler {
+	return &OAuthClientHandler{Service: service}
+}
+
+// Token implements the client_credentials grant (RFC 6749 section 4.4), the response is not wrapped in response.Success.
+func (h *OAuthClientHandler) Token(c echo.Context) error {
+	var req dto.TokenRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Token - Failed to bind request body")
+		return c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: "invalid_request"})
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Token - Validation failed")
+		return c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "grant_type is required"})
+	}
+	if req.GrantType != "client_credentials" {
+		return c.JSON(http.StatusBadRequest, dto.OAuthErrorResponse{Error: "unsupported_grant_type"})
+	}
+
+	res, err := h.Service.IssueToken(c.Request().Context(), middleware.GetAPIClientFromContext(c), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::OAuthClient.Token - Service returned error")
+		code, _ := errmsg.Errors[any](err)
+		if code == http.StatusBadRequest {
+			return c.JSON(code, dto.OAuthErrorResponse{Error: "invalid_scope", ErrorDescription: err.Error()})
+		}
+		return c.JSON(code, dto.OAuthErrorResponse{Error: "server_error"})
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *OAuthClientHandler) List(c echo.Context) error {
+	res, err := h.Service.List(c.Request().Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::OAuthClient.List - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Client berhasil dimuat"))
+}
+
+func (h *OAuthClientHandler) Get(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.Get(c.Request().Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::OAuthClient.Get - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Client berhasil dimuat"))
+}
+
+func (h *OAuthClientHandler) Create(c echo.Context) error {
+	var req dto.ClientCreateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Create - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Create - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.Create(c.Request().Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::OAuthClient.Create - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusCreated, response.Success(res, "Client berhasil dibuat, simpan client secret ini karena tidak akan ditampilkan lagi"))
+}
+
+func (h *OAuthClientHandler) Update(c echo.Context) error {
+	id := c.Param("id")
+
+	var req dto.ClientUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Update - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Update - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.Update(c.Request().Context(), id, req)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::OAuthClient.Update - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Client berhasil diperbarui"))
+}
+
+func (h *OAuthClientHandler) RotateSecret(c echo.Context) error {
+	id := c.Param("id")
+
+	res, err := h.Service.RotateSecret(c.Request().Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::OAuthClient.RotateSecret - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "Client secret berhasil dirotasi, simpan client secret ini karena tidak akan ditampilkan lagi"))
+}
+
+func (h *OAuthClientHandler) Revoke(c echo.Context) error {
+	id := c.Param("id")
+
+	if err := h.Service.Revoke(c.Request().Context(), id); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::OAuthClient.Revoke - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(nil, "Client berhasil dicabut"))
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"echo-jwt-starter/internal/entity"
+)
+
+type ClientRepository interface {
+	List(ctx context.Context) ([]entity.ClientDB, error)
+	FindById(ctx context.Context, id string) (*entity.ClientDB, error)
+	Create(ctx context.Context, client *entity.ClientDB) error
+	// Update stores name and scopes of the client.
+	Update(ctx context.Context, client *entity.ClientDB) error
+	// RotateSecret replaces the secret of the client, the previous secret stops working immediately.
+	RotateSecret(ctx context.Context, id string, secretHash string) error
+	Revoke(ctx context.Context, id string) error
+	// TouchLastUsed records usage, at most once per minute per client.
+	TouchLastUsed(ctx context.Context, id string) error
+}
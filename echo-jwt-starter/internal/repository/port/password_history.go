@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+	"echo-jwt-starter/internal/entity"
+)
+
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, entry *entity.PasswordHistoryDB) error
+	// ListRecentHashes returns the hashes of the last limit passwords of the user, newest first.
+	ListRecentHashes(ctx context.Context, userId string, limit int) ([]string, error)
+	// Prune deletes all but the newest keep entries of the user.
+	Prune(ctx context.Context, userId string, keep int) error
+}
@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"echo-jwt-starter/internal/entity"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshTokenDB) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshTokenDB, error)
+	// MarkRotated returns false when the token was already rotated or revoked by another request.
+	MarkRotated(ctx context.Context, id string) (bool, error)
+	RevokeFamily(ctx context.Context, familyId string) error
+	RevokeByUser(ctx context.Context, userId string) error
+	// RevokeOtherFamilies revokes the tokens of every family of the user except keepFamilyId.
+	RevokeOtherFamilies(ctx context.Context, userId string, keepFamilyId string) error
+}
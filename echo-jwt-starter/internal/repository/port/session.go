@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"echo-jwt-starter/internal/entity"
+	"time"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.SessionDB) error
+	FindById(ctx context.Context, id string) (*entity.SessionDB, error)
+	// ListActiveByUser returns the sessions that are not revoked and were seen after since, newest first.
+	ListActiveByUser(ctx context.Context, userId string, since time.Time) ([]entity.SessionDB, error)
+	// Touch updates last_seen_at of the session owning the refresh token family.
+	Touch(ctx context.Context, familyId string) error
+	RevokeByFamily(ctx context.Context, familyId string) error
+	// RevokeByUser revokes every active session of the user except the one of exceptFamilyId
+	// (empty revokes all) and returns the revoked token families.
+	RevokeByUser(ctx context.Context, userId string, exceptFamilyId string) ([]string, error)
+}
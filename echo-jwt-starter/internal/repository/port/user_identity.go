@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"echo-jwt-starter/internal/entity"
+)
+
+type UserIdentityRepository interface {
+	FindByProviderSubject(ctx context.Context, provider string, subject string) (*entity.UserIdentityDB, error)
+	Create(ctx context.Context, identity *entity.UserIdentityDB) error
+	TouchLastLogin(ctx context.Context, id string) error
+}
+
+type OidcStateRepository interface {
+	Create(ctx context.Context, state *entity.OidcStateDB) error
+	// Consume deletes and returns the state so it can be used only once.
+	Consume(ctx context.Context, stateHash string) (*entity.OidcStateDB, error)
+}
@@ -0,0 +1,160 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type ApiKeyRepository struct {
+	DB DBExecutor
+}
+
+func NewApiKeyRepositoryImpl(db DBExecutor) port.ApiKeyRepository {
+	return &ApiKeyRepository{
+		DB: db,
+	}
+}
+
+const apiKeyColumns = `ak.id, ak.name, ak.owner, ak.prefix, ak.key_hash, ak.scopes, ak.expires_at, ak.last_used_at, ak.revoked_at, ak.created_at, ak.updated_at`
+
+func scanApiKey(row rowScanner) (*entity.ApiKeyDB, error) {
+	var key entity.ApiKeyDB
+	var owner sql.NullString
+	if err := row.Scan(
+		&key.Id,
+		&key.Name,
+		&owner,
+		&key.Prefix,
+		&key.KeyHash,
+		pq.Array(&key.Scopes),
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	key.Owner = owner.String
+	return &key, nil
+}
+
+func (r *ApiKeyRepository) List(ctx context.Context) ([]entity.ApiKeyDB, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM public.api_keys ak ORDER BY ak.created_at DESC`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repo::ApiKey.List - Failed to list api keys")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list api keys"))
+	}
+	defer rows.Close()
+
+	keys := make([]entity.ApiKeyDB, 0)
+	for rows.Next() {
+		key, err := scanApiKey(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("repo::ApiKey.List - Failed to scan api key")
+			return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list api keys"))
+		}
+		keys = append(keys, *key)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("repo::ApiKey.List - Failed to iterate api keys")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list api keys"))
+	}
+
+	return keys, nil
+}
+
+func (r *ApiKeyRepository) FindById(ctx context.Context, id string) (*entity.ApiKeyDB, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM public.api_keys ak WHERE ak.id = $1 LIMIT 1`
+	return r.findOne(ctx, "FindById", query, id)
+}
+
+func (r *ApiKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.ApiKeyDB, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM public.api_keys ak WHERE ak.prefix = $1 LIMIT 1`
+	return r.findOne(ctx, "FindByPrefix", query, prefix)
+}
+
+func (r *ApiKeyRepository) findOne(ctx context.Context, method, query string, arg string) (*entity.ApiKeyDB, error) {
+	key, err := scanApiKey(r.DB.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.NotFound))
+		}
+		log.Error().Err(err).Msg("repo::ApiKey." + method + " - Failed to get api key")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get api key"))
+	}
+	return key, nil
+}
+
+func (r *ApiKeyRepository) Create(ctx context.Context, key *entity.ApiKeyDB) error {
+	query := `
+		INSERT INTO public.api_keys (id, name, owner, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7);
+	`
+	if _, err := r.DB.ExecContext(ctx, query, key.Id, key.Name, key.Owner, key.Prefix, key.KeyHash, pq.Array(key.Scopes), key.ExpiresAt); err != nil {
+		log.Error().Err(err).Str("name", key.Name).Msg("repo::ApiKey.Create - Failed to create api key")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to create api key"))
+	}
+	return nil
+}
+
+func (r *ApiKeyRepository) Update(ctx context.Context, key *entity.ApiKeyDB) error {
+	query := `
+		UPDATE public.api_keys
+		SET name = $2, owner = NULLIF($3, ''), scopes = $4, expires_at = $5, updated_at = now()
+		WHERE id = $1;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, key.Id, key.Name, key.Owner, pq.Array(key.Scopes), key.ExpiresAt); err != nil {
+		log.Error().Err(err).Str("id", key.Id).Msg("repo::ApiKey.Update - Failed to update api key")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update api key"))
+	}
+	return nil
+}
+
+func (r *ApiKeyRepository) Rotate(ctx context.Context, id string, prefix string, keyHash string) error {
+	query := `
+		UPDATE public.api_keys
+		SET prefix = $2, key_hash = $3, updated_at = now()
+		WHERE id = $1 AND revoked_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id, prefix, keyHash); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::ApiKey.Rotate - Failed to rotate api key")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to rotate api key"))
+	}
+	return nil
+}
+
+func (r *ApiKeyRepository) Revoke(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.api_keys
+		SET revoked_at = COALESCE(revoked_at, now()), updated_at = now()
+		WHERE id = $1;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::ApiKey.Revoke - Failed to revoke api key")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke api key"))
+	}
+	return nil
+}
+
+func (r *ApiKeyRepository) TouchLastUsed(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.api_keys
+		SET last_used_at = now()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - INTERVAL '1 minute');
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::ApiKey.TouchLastUsed - Failed to update last used")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update api key"))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package psql
+
+import (
+	"context"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MfaRecoveryCodeRepository struct {
+	DB DBExecutor
+}
+
+func NewMfaRecoveryCodeRepositoryImpl(db DBExecutor) port.MfaRecoveryCodeRepository {
+	return &MfaRecoveryCodeRepository{
+		DB: db,
+	}
+}
+
+func (r *MfaRecoveryCodeRepository) ReplaceAll(ctx context.Context, userId string, codeHashes []string) error {
+	if err := r.DeleteByUser(ctx, userId); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO public.mfa_recovery_codes (user_id, code_hash)
+		VALUES ($1, $2);
+	`
+	for _, hash := range codeHashes {
+		if _, err := r.DB.ExecContext(ctx, query, userId, hash); err != nil {
+			log.Error().Err(err).Str("user_id", userId).Msg("repo::MfaRecoveryCode.ReplaceAll - Failed to store recovery code")
+			return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to store recovery codes"))
+		}
+	}
+	return nil
+}
+
+func (r *MfaRecoveryCodeRepository) Use(ctx context.Context, userId string, codeHash string) (bool, error) {
+	query := `
+		UPDATE public.mfa_recovery_codes
+		SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, userId, codeHash)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::MfaRecoveryCode.Use - Failed to use recovery code")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to use recovery code"))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::MfaRecoveryCode.Use - Failed to check rows affected")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to use recovery code"))
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *MfaRecoveryCodeRepository) CountUnused(ctx context.Context, userId string) (int, error) {
+	query := `
+		SELECT COUNT(1)
+		FROM public.mfa_recovery_codes rc
+		WHERE rc.user_id = $1 AND rc.used_at IS NULL;
+	`
+	var count int
+	if err := r.DB.QueryRowContext(ctx, query, userId).Scan(&count); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::MfaRecoveryCode.CountUnused - Failed to count recovery codes")
+		return 0, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to count recovery codes"))
+	}
+	return count, nil
+}
+
+func (r *MfaRecoveryCodeRepository) DeleteByUser(ctx context.Context, userId string) error {
+	query := `DELETE FROM public.mfa_recovery_codes WHERE user_id = $1;`
+	if _, err := r.DB.ExecContext(ctx, query, userId); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::MfaRecoveryCode.DeleteByUser - Failed to delete recovery codes")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to delete recovery codes"))
+	}
+	return nil
+}
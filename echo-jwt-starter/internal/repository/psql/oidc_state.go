@@ -0,0 +1,66 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type OidcStateRepository struct {
+	DB DBExecutor
+}
+
+func NewOidcStateRepositoryImpl(db DBExecutor) port.OidcStateRepository {
+	return &OidcStateRepository{
+		DB: db,
+	}
+}
+
+func (r *OidcStateRepository) Create(ctx context.Context, state *entity.OidcStateDB) error {
+	// Opportunistically purge abandoned login attempts
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM public.oidc_states WHERE expires_at < now();`); err != nil {
+		log.Warn().Err(err).Msg("repo::OidcState.Create - Failed to purge expired states")
+	}
+
+	query := `
+		INSERT INTO public.oidc_states (state_hash, provider, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+	if _, err := r.DB.ExecContext(ctx, query, state.StateHash, state.Provider, state.Nonce, state.CodeVerifier, state.ExpiresAt); err != nil {
+		log.Error().Err(err).Str("provider", state.Provider).Msg("repo::OidcState.Create - Failed to store oidc state")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to store oidc state"))
+	}
+	return nil
+}
+
+func (r *OidcStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OidcStateDB, error) {
+	var state entity.OidcStateDB
+	query := `
+		DELETE FROM public.oidc_states
+		WHERE state_hash = $1
+		RETURNING state_hash, provider, nonce, code_verifier, expires_at, created_at;
+	`
+
+	if err := r.DB.QueryRowContext(ctx, query, stateHash).
+		Scan(
+			&state.StateHash,
+			&state.Provider,
+			&state.Nonce,
+			&state.CodeVerifier,
+			&state.ExpiresAt,
+			&state.CreatedAt,
+		); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.NotFound))
+		}
+		log.Error().Err(err).Msg("repo::OidcState.Consume - Failed to consume oidc state")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get oidc state"))
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,123 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type RefreshTokenRepository struct {
+	DB DBExecutor
+}
+
+func NewRefreshTokenRepositoryImpl(db DBExecutor) port.RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		DB: db,
+	}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshTokenDB) error {
+	query := `
+		INSERT INTO public.refresh_tokens (id, user_id, family_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+	if _, err := r.DB.ExecContext(ctx, query, token.Id, token.UserId, token.FamilyId, token.TokenHash, token.ExpiresAt); err != nil {
+		log.Error().Err(err).Str("user_id", token.UserId).Msg("repo::RefreshToken.Create - Failed to store refresh token")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to store refresh token"))
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshTokenDB, error) {
+	var token entity.RefreshTokenDB
+	query := `
+		SELECT rt.id, rt.user_id, rt.family_id, rt.token_hash, rt.expires_at, rt.rotated_at, rt.revoked_at, rt.created_at
+		FROM public.refresh_tokens rt
+		WHERE rt.token_hash = $1
+		LIMIT 1
+	`
+
+	if err := r.DB.QueryRowContext(ctx, query, tokenHash).
+		Scan(
+			&token.Id,
+			&token.UserId,
+			&token.FamilyId,
+			&token.TokenHash,
+			&token.ExpiresAt,
+			&token.RotatedAt,
+			&token.RevokedAt,
+			&token.CreatedAt,
+		); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Msg("repo::RefreshToken.FindByHash - Refresh token not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.NotFound))
+		}
+		log.Error().Err(err).Msg("repo::RefreshToken.FindByHash - Failed to get refresh token")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get refresh token"))
+	}
+
+	return &token, nil
+}
+
+func (r *RefreshTokenRepository) MarkRotated(ctx context.Context, id string) (bool, error) {
+	query := `
+		UPDATE public.refresh_tokens
+		SET rotated_at = now()
+		WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::RefreshToken.MarkRotated - Failed to rotate refresh token")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to rotate refresh token"))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::RefreshToken.MarkRotated - Failed to check rows affected")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to rotate refresh token"))
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyId string) error {
+	query := `
+		UPDATE public.refresh_tokens
+		SET revoked_at = now()
+		WHERE family_id = $1 AND revoked_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, familyId); err != nil {
+		log.Error().Err(err).Str("family_id", familyId).Msg("repo::RefreshToken.RevokeFamily - Failed to revoke token family")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke token family"))
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeByUser(ctx context.Context, userId string) error {
+	query := `
+		UPDATE public.refresh_tokens
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, userId); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::RefreshToken.RevokeByUser - Failed to revoke user tokens")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke user tokens"))
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeOtherFamilies(ctx context.Context, userId string, keepFamilyId string) error {
+	query := `
+		UPDATE public.refresh_tokens
+		SET revoked_at = now()
+		WHERE user_id = $1 AND family_id::text <> $2 AND revoked_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, userId, keepFamilyId); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::RefreshToken.RevokeOtherFamilies - Failed to revoke user tokens")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke user tokens"))
+	}
+	return nil
+}
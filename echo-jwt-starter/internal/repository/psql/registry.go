@@ -57,3 +57,87 @@ func (r *RepositoryRegistry) GetUserRepository() port.UserRepository {
 	}
 	return NewUserRepositoryImpl(r.db)
 }
+
+func (r *RepositoryRegistry) GetRefreshTokenRepository() port.RefreshTokenRepository {
+	if r.dbExecutor != nil {
+		return NewRefreshTokenRepositoryImpl(r.dbExecutor)
+	}
+	return NewRefreshTokenRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetRevokedTokenRepository() port.RevokedTokenRepository {
+	if r.dbExecutor != nil {
+		return NewRevokedTokenRepositoryImpl(r.dbExecutor)
+	}
+	return NewRevokedTokenRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetUserTokenRepository() port.UserTokenRepository {
+	if r.dbExecutor != nil {
+		return NewUserTokenRepositoryImpl(r.dbExecutor)
+	}
+	return NewUserTokenRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetUserMfaRepository() port.UserMfaRepository {
+	if r.dbExecutor != nil {
+		return NewUserMfaRepositoryImpl(r.dbExecutor)
+	}
+	return NewUserMfaRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetMfaRecoveryCodeRepository() port.MfaRecoveryCodeRepository {
+	if r.dbExecutor != nil {
+		return NewMfaRecoveryCodeRepositoryImpl(r.dbExecutor)
+	}
+	return NewMfaRecoveryCodeRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetUserIdentityRepository() port.UserIdentityRepository {
+	if r.dbExecutor != nil {
+		return NewUserIdentityRepositoryImpl(r.dbExecutor)
+	}
+	return NewUserIdentityRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetOidcStateRepository() port.OidcStateRepository {
+	if r.dbExecutor != nil {
+		return NewOidcStateRepositoryImpl(r.dbExecutor)
+	}
+	return NewOidcStateRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetApiKeyRepository() port.ApiKeyRepository {
+	if r.dbExecutor != nil {
+		return NewApiKeyRepositoryImpl(r.dbExecutor)
+	}
+	return NewApiKeyRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetRoleRepository() port.RoleRepository {
+	if r.dbExecutor != nil {
+		return NewRoleRepositoryImpl(r.dbExecutor)
+	}
+	return NewRoleRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetSessionRepository() port.SessionRepository {
+	if r.dbExecutor != nil {
+		return NewSessionRepositoryImpl(r.dbExecutor)
+	}
+	return NewSessionRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetPasswordHistoryRepository() port.PasswordHistoryRepository {
+	if r.dbExecutor != nil {
+		return NewPasswordHistoryRepositoryImpl(r.dbExecutor)
+	}
+	return NewPasswordHistoryRepositoryImpl(r.db)
+}
+
+func (r *RepositoryRegistry) GetClientRepository() port.ClientRepository {
+	if r.dbExecutor != nil {
+		return NewClientRepositoryImpl(r.dbExecutor)
+	}
+	return NewClientRepositoryImpl(r.db)
+}
@@ -0,0 +1,233 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/rbac"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type RoleRepository struct {
+	DB DBExecutor
+}
+
+func NewRoleRepositoryImpl(db DBExecutor) port.RoleRepository {
+	return &RoleRepository{
+		DB: db,
+	}
+}
+
+const roleSelect = `
+	SELECT r.id, r.name, r.description, r.created_at,
+		COALESCE(array_agg(p.name ORDER BY p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+	FROM public.roles r
+	LEFT JOIN public.role_permissions rp ON rp.role_id = r.id
+	LEFT JOIN public.permissions p ON p.id = rp.permission_id
+`
+
+func scanRole(row rowScanner) (*entity.RoleDB, error) {
+	var role entity.RoleDB
+	var description sql.NullString
+	if err := row.Scan(
+		&role.Id,
+		&role.Name,
+		&description,
+		&role.CreatedAt,
+		pq.Array(&role.Permissions),
+	); err != nil {
+		return nil, err
+	}
+	role.Description = description.String
+	return &role, nil
+}
+
+func (r *RoleRepository) List(ctx context.Context) ([]entity.RoleDB, error) {
+	query := roleSelect + ` GROUP BY r.id ORDER BY r.name`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repo::Role.List - Failed to list roles")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list roles"))
+	}
+	defer rows.Close()
+
+	roles := make([]entity.RoleDB, 0)
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("repo::Role.List - Failed to scan role")
+			return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list roles"))
+		}
+		roles = append(roles, *role)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("repo::Role.List - Failed to iterate roles")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list roles"))
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepository) FindByName(ctx context.Context, name string) (*entity.RoleDB, error) {
+	query := roleSelect + ` WHERE r.name = $1 GROUP BY r.id`
+
+	role, err := scanRole(r.DB.QueryRowContext(ctx, query, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Str("name", name).Msg("repo::Role.FindByName - Role not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Role not found"))
+		}
+		log.Error().Err(err).Str("name", name).Msg("repo::Role.FindByName - Failed to get role")
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *RoleRepository) Create(ctx context.Context, role *entity.RoleDB) error {
+	query := `
+		INSERT INTO public.roles (id, name, description)
+		VALUES ($1, $2, NULLIF($3, ''))
+		ON CONFLICT (name) DO NOTHING;
+	`
+	result, err := r.DB.ExecContext(ctx, query, role.Id, role.Name, role.Description)
+	if err != nil {
+		log.Error().Err(err).Str("name", role.Name).Msg("repo::Role.Create - Failed to create role")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to create role"))
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		log.Error().Err(err).Str("name", role.Name).Msg("repo::Role.Create - Failed to check rows affected")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to create role"))
+	} else if rowsAffected != 1 {
+		log.Warn().Str("name", role.Name).Msg("repo::Role.Create - Role already exists")
+		return errmsg.NewCustomErrors(409, errmsg.WithErrors("name", "Role already exists"))
+	}
+	return nil
+}
+
+func (r *RoleRepository) ListPermissions(ctx context.Context) ([]entity.PermissionDB, error) {
+	query := `
+		SELECT p.id, p.name, p.description, p.created_at
+		FROM public.permissions p
+		ORDER BY p.name
+	`
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repo::Role.ListPermissions - Failed to list permissions")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list permissions"))
+	}
+	defer rows.Close()
+
+	permissions := make([]entity.PermissionDB, 0)
+	for rows.Next() {
+		var permission entity.PermissionDB
+		var description sql.NullString
+		if err = rows.Scan(&permission.Id, &permission.Name, &description, &permission.CreatedAt); err != nil {
+			log.Error().Err(err).Msg("repo::Role.ListPermissions - Failed to scan permission")
+			return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list permissions"))
+		}
+		permission.Description = description.String
+		permissions = append(permissions, permission)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("repo::Role.ListPermissions - Failed to iterate permissions")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list permissions"))
+	}
+
+	return permissions, nil
+}
+
+func (r *RoleRepository) SetPermissions(ctx context.Context, roleId string, permissions []string) error {
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM public.role_permissions WHERE role_id = $1;`, roleId); err != nil {
+		log.Error().Err(err).Str("role_id", roleId).Msg("repo::Role.SetPermissions - Failed to clear permissions")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update role permissions"))
+	}
+
+	query := `
+		INSERT INTO public.role_permissions (role_id, permission_id)
+		SELECT $1, p.id FROM public.permissions p
+		WHERE p.name = ANY($2)
+		ON CONFLICT DO NOTHING;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, roleId, pq.Array(permissions)); err != nil {
+		log.Error().Err(err).Str("role_id", roleId).Msg("repo::Role.SetPermissions - Failed to insert permissions")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update role permissions"))
+	}
+	return nil
+}
+
+func (r *RoleRepository) UserGrants(ctx context.Context, userId string) (rbac.Grants, error) {
+	query := `
+		SELECT r.name, COALESCE(array_agg(p.name) FILTER (WHERE p.name IS NOT NULL), '{}')
+		FROM public.user_roles ur
+		JOIN public.roles r ON r.id = ur.role_id
+		LEFT JOIN public.role_permissions rp ON rp.role_id = r.id
+		LEFT JOIN public.permissions p ON p.id = rp.permission_id
+		WHERE ur.user_id = $1
+		GROUP BY r.name
+		ORDER BY r.name
+	`
+	rows, err := r.DB.QueryContext(ctx, query, userId)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::Role.UserGrants - Failed to load grants")
+		return rbac.Grants{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to load permissions"))
+	}
+	defer rows.Close()
+
+	grants := rbac.Grants{Roles: make([]string, 0), Permissions: make([]string, 0)}
+	seen := make(map[string]struct{})
+	for rows.Next() {
+		var role string
+		var permissions []string
+		if err = rows.Scan(&role, pq.Array(&permissions)); err != nil {
+			log.Error().Err(err).Str("user_id", userId).Msg("repo::Role.UserGrants - Failed to scan grant")
+			return rbac.Grants{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to load permissions"))
+		}
+		grants.Roles = append(grants.Roles, role)
+		for _, p := range permissions {
+			if _, ok := seen[p]; !ok {
+				seen[p] = struct{}{}
+				grants.Permissions = append(grants.Permissions, p)
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::Role.UserGrants - Failed to iterate grants")
+		return rbac.Grants{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to load permissions"))
+	}
+
+	return grants, nil
+}
+
+func (r *RoleRepository) Grant(ctx context.Context, userId string, roleId string, grantedBy string) error {
+	query := `
+		INSERT INTO public.user_roles (user_id, role_id, granted_by)
+		VALUES ($1, $2, NULLIF($3, '')::uuid)
+		ON CONFLICT (user_id, role_id) DO NOTHING;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, userId, roleId, grantedBy); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Str("role_id", roleId).Msg("repo::Role.Grant - Failed to grant role")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to grant role"))
+	}
+	return nil
+}
+
+func (r *RoleRepository) Revoke(ctx context.Context, userId string, roleId string) (bool, error) {
+	query := `DELETE FROM public.user_roles WHERE user_id = $1 AND role_id = $2;`
+	result, err := r.DB.ExecContext(ctx, query, userId, roleId)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Str("role_id", roleId).Msg("repo::Role.Revoke - Failed to revoke role")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke role"))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Str("role_id", roleId).Msg("repo::Role.Revoke - Failed to check rows affected")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke role"))
+	}
+	return rowsAffected > 0, nil
+}
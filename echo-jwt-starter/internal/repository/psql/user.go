@@ -6,6 +6,11 @@ import (
 	"echo-jwt-starter/internal/entity"
 	"echo-jwt-starter/internal/repository/port"
 	"echo-jwt-starter/pkg/errmsg"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
@@ -20,23 +25,34 @@ func NewUserRepositoryImpl(db DBExecutor) port.UserRepository {
 	}
 }
 
-func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.UserDB, error) {
+const userColumns = `u.id, u.email, COALESCE(u.name, ''), COALESCE(u.avatar_url, ''), u.password, u.role, u.email_verified_at, u.failed_login_count, u.locked_until, u.disabled_at, u.deleted_at, u.created_at`
+
+func scanUser(row rowScanner) (*entity.UserDB, error) {
 	var user entity.UserDB
-	// your implementation here
-	query := `
-		SELECT u.id, u.email, u.password, u.role
-		FROM public.users u
-		WHERE u.email = $1 AND u.deleted_at IS NULL
-		LIMIT 1
-	`
+	if err := row.Scan(
+		&user.Id,
+		&user.Email,
+		&user.Name,
+		&user.AvatarURL,
+		&user.Password,
+		&user.Role,
+		&user.EmailVerifiedAt,
+		&user.FailedLoginCount,
+		&user.LockedUntil,
+		&user.DisabledAt,
+		&user.DeletedAt,
+		&user.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
 
-	if err := r.DB.QueryRowContext(ctx, query, email).
-		Scan(
-			&user.Id,
-			&user.Email,
-			&user.Password,
-			&user.Role,
-		); err != nil {
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.UserDB, error) {
+	query := `SELECT ` + userColumns + ` FROM public.users u WHERE u.email = $1 AND u.deleted_at IS NULL LIMIT 1`
+
+	user, err := scanUser(r.DB.QueryRowContext(ctx, query, email))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Error().Err(err).Str("email", email).Msg("repo::FindByEmail - User not found")
 			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
@@ -45,7 +61,97 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity
 		return nil, err
 	}
 
-	return &user, nil
+	return user, nil
+}
+
+func (r *UserRepository) FindById(ctx context.Context, id string) (*entity.UserDB, error) {
+	query := `SELECT ` + userColumns + ` FROM public.users u WHERE u.id = $1 AND u.deleted_at IS NULL LIMIT 1`
+
+	user, err := scanUser(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Error().Err(err).Str("id", id).Msg("repo::FindById - User not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+		}
+		log.Error().Err(err).Str("id", id).Msg("repo::FindById - Failed to get user")
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) FindByIdWithDeleted(ctx context.Context, id string) (*entity.UserDB, error) {
+	query := `SELECT ` + userColumns + ` FROM public.users u WHERE u.id = $1 LIMIT 1`
+
+	user, err := scanUser(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+		}
+		log.Error().Err(err).Str("id", id).Msg("repo::FindByIdWithDeleted - Failed to get user")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get user"))
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) List(ctx context.Context, filter entity.UserFilter) ([]entity.UserDB, int, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	switch filter.Status {
+	case "deleted":
+		conditions = append(conditions, "u.deleted_at IS NOT NULL")
+	case "disabled":
+		conditions = append(conditions, "u.deleted_at IS NULL", "u.disabled_at IS NOT NULL")
+	case "active":
+		conditions = append(conditions, "u.deleted_at IS NULL", "u.disabled_at IS NULL")
+	default:
+		conditions = append(conditions, "u.deleted_at IS NULL")
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		conditions = append(conditions, fmt.Sprintf("(u.email ILIKE $%d OR u.name ILIKE $%d)", len(args), len(args)))
+	}
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		conditions = append(conditions, fmt.Sprintf("u.role = $%d", len(args)))
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM public.users u`+where, args...).Scan(&total); err != nil {
+		log.Error().Err(err).Msg("repo::User.List - Failed to count users")
+		return nil, 0, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list users"))
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := `SELECT ` + userColumns + ` FROM public.users u` + where +
+		fmt.Sprintf(" ORDER BY u.created_at DESC, u.id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("repo::User.List - Failed to list users")
+		return nil, 0, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list users"))
+	}
+	defer rows.Close()
+
+	users := make([]entity.UserDB, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("repo::User.List - Failed to scan user")
+			return nil, 0, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list users"))
+		}
+		users = append(users, *user)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("repo::User.List - Failed to iterate users")
+		return nil, 0, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list users"))
+	}
+
+	return users, total, nil
 }
 
 func (r *UserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
@@ -83,3 +189,188 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.UserDB) error
 	}
 	return nil
 }
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id string, hashedPassword string) error {
+	query := `
+		UPDATE public.users
+		SET password = $2, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id, hashedPassword)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::UpdatePassword - Failed to update password")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update password"))
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::UpdatePassword - Failed to check rows affected")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update password"))
+	} else if rowsAffected != 1 {
+		log.Warn().Str("id", id).Int64("rowsAffected", rowsAffected).Msg("repo::UpdatePassword - User not found")
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+	}
+	return nil
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.users
+		SET email_verified_at = COALESCE(email_verified_at, now()), updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::MarkEmailVerified - Failed to mark email as verified")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to verify email"))
+	}
+	return nil
+}
+
+func (r *UserRepository) IncrementFailedLogins(ctx context.Context, id string) (int, error) {
+	query := `
+		UPDATE public.users
+		SET failed_login_count = failed_login_count + 1
+		WHERE id = $1
+		RETURNING failed_login_count;
+	`
+	var count int
+	if err := r.DB.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::IncrementFailedLogins - Failed to increment failed login count")
+		return 0, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	}
+	return count, nil
+}
+
+func (r *UserRepository) LockUntil(ctx context.Context, id string, until time.Time) error {
+	query := `
+		UPDATE public.users
+		SET locked_until = $2
+		WHERE id = $1;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id, until); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::LockUntil - Failed to lock user")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	}
+	return nil
+}
+
+func (r *UserRepository) ResetFailedLogins(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.users
+		SET failed_login_count = 0, locked_until = NULL
+		WHERE id = $1;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::ResetFailedLogins - Failed to reset failed login count")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, user *entity.UserDB) error {
+	query := `
+		UPDATE public.users
+		SET name = NULLIF($2, ''), avatar_url = NULLIF($3, ''), updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, user.Id, user.Name, user.AvatarURL)
+	if err != nil {
+		log.Error().Err(err).Str("id", user.Id).Msg("repo::UpdateProfile - Failed to update profile")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		log.Error().Err(err).Str("id", user.Id).Msg("repo::UpdateProfile - Failed to check rows affected")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	} else if rowsAffected != 1 {
+		log.Warn().Str("id", user.Id).Int64("rowsAffected", rowsAffected).Msg("repo::UpdateProfile - User not found")
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+	}
+	return nil
+}
+
+func (r *UserRepository) SoftDelete(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.users
+		SET deleted_at = now(), updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::SoftDelete - Failed to delete user")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to delete user"))
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::SoftDelete - Failed to check rows affected")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to delete user"))
+	} else if rowsAffected != 1 {
+		log.Warn().Str("id", id).Int64("rowsAffected", rowsAffected).Msg("repo::SoftDelete - User not found")
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id string, role string) error {
+	query := `
+		UPDATE public.users
+		SET role = $2, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id, role)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::UpdateRole - Failed to update role")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::UpdateRole - Failed to check rows affected")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	} else if rowsAffected != 1 {
+		log.Warn().Str("id", id).Int64("rowsAffected", rowsAffected).Msg("repo::UpdateRole - User not found")
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+	}
+	return nil
+}
+
+func (r *UserRepository) SetDisabled(ctx context.Context, id string, disabled bool) error {
+	query := `
+		UPDATE public.users
+		SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, now()) ELSE NULL END, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id, disabled)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::SetDisabled - Failed to update user")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::SetDisabled - Failed to check rows affected")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	} else if rowsAffected != 1 {
+		log.Warn().Str("id", id).Int64("rowsAffected", rowsAffected).Msg("repo::SetDisabled - User not found")
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+	}
+	return nil
+}
+
+func (r *UserRepository) Restore(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.users
+		SET deleted_at = NULL, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NOT NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+			log.Warn().Str("id", id).Msg("repo::Restore - Email is used by another account")
+			return errmsg.NewCustomErrors(409, errmsg.WithErrors("email", "Email is already used by another account"))
+		}
+		log.Error().Err(err).Str("id", id).Msg("repo::Restore - Failed to restore user")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to restore user"))
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::Restore - Failed to check rows affected")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to restore user"))
+	} else if rowsAffected != 1 {
+		log.Warn().Str("id", id).Int64("rowsAffected", rowsAffected).Msg("repo::Restore - Deleted user not found")
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type UserIdentityRepository struct {
+	DB DBExecutor
+}
+
+func NewUserIdentityRepositoryImpl(db DBExecutor) port.UserIdentityRepository {
+	return &UserIdentityRepository{
+		DB: db,
+	}
+}
+
+func (r *UserIdentityRepository) FindByProviderSubject(ctx context.Context, provider string, subject string) (*entity.UserIdentityDB, error) {
+	var identity entity.UserIdentityDB
+	var email sql.NullString
+	query := `
+		SELECT ui.id, ui.user_id, ui.provider, ui.subject, ui.email, ui.created_at, ui.last_login_at
+		FROM public.user_identities ui
+		WHERE ui.provider = $1 AND ui.subject = $2
+		LIMIT 1
+	`
+
+	if err := r.DB.QueryRowContext(ctx, query, provider, subject).
+		Scan(
+			&identity.Id,
+			&identity.UserId,
+			&identity.Provider,
+			&identity.Subject,
+			&email,
+			&identity.CreatedAt,
+			&identity.LastLoginAt,
+		); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.NotFound))
+		}
+		log.Error().Err(err).Str("provider", provider).Msg("repo::UserIdentity.FindByProviderSubject - Failed to get user identity")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get user identity"))
+	}
+	identity.Email = email.String
+
+	return &identity, nil
+}
+
+func (r *UserIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentityDB) error {
+	query := `
+		INSERT INTO public.user_identities (id, user_id, provider, subject, email, last_login_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), now());
+	`
+	if _, err := r.DB.ExecContext(ctx, query, identity.Id, identity.UserId, identity.Provider, identity.Subject, identity.Email); err != nil {
+		log.Error().Err(err).Str("user_id", identity.UserId).Str("provider", identity.Provider).Msg("repo::UserIdentity.Create - Failed to link user identity")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to link user identity"))
+	}
+	return nil
+}
+
+func (r *UserIdentityRepository) TouchLastLogin(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.user_identities
+		SET last_login_at = now()
+		WHERE id = $1;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::UserIdentity.TouchLastLogin - Failed to update last login")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user identity"))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"echo-jwt-starter/internal/handler"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/internal/service"
+	"echo-jwt-starter/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterAdminRoutes(g *echo.Group, repo port.RepositoryRegistry) {
+	adminUserService := service.NewAdminUserService(repo)
+	adminUserHandler := handler.NewAdminUserHandler(adminUserService)
+	adminRoleService := service.NewAdminRoleService(repo)
+	adminRoleHandler := handler.NewAdminRoleHandler(adminRoleService)
+	apiKeyService := service.NewApiKeyService(repo)
+	apiKeyHandler := handler.NewApiKeyHandler(apiKeyService)
+	oauthClientService := service.NewOAuthClientService(repo)
+	oauthClientHandler := handler.NewOAuthClientHandler(oauthClientService)
+
+	g.Use(middleware.AuthBearer, middleware.RequireScopes("admin"), middleware.AuthRole([]string{"admin"}))
+
+	users := g.Group("/users")
+	users.GET("", adminUserHandler.List, middleware.RequirePermission("users:read"))
+	users.GET("/:id", adminUserHandler.Get, middleware.RequirePermission("users:read"))
+	users.PUT("/:id/role", adminUserHandler.UpdateRole, middleware.RequirePermission("users:write"))
+	users.POST("/:id/disable", adminUserHandler.Disable, middleware.RequirePermission("users:write"))
+	users.POST("/:id/enable", adminUserHandler.Enable, middleware.RequirePermission("users:write"))
+	users.DELETE("/:id", adminUserHandler.Delete, middleware.RequirePermission("users:write"))
+	users.POST("/:id/restore", adminUserHandler.Restore, middleware.RequirePermission("users:write"))
+	users.POST("/:id/unlock", adminUserHandler.Unlock, middleware.RequirePermission("users:write"))
+	users.GET("/:id/roles", adminRoleHandler.UserRoles, middleware.RequirePermission("roles:read"))
+	users.POST("/:id/roles", adminRoleHandler.GrantRole, middleware.RequirePermission("roles:write"))
+	users.DELETE("/:id/roles/:role", adminRoleHandler.RevokeRole, middleware.RequirePermission("roles:write"))
+
+	roles := g.Group("/roles")
+	roles.GET("", adminRoleHandler.ListRoles, middleware.RequirePermission("roles:read"))
+	roles.POST("", adminRoleHandler.CreateRole, middleware.RequirePermission("roles:write"))
+	roles.PUT("/:name/permissions", adminRoleHandler.SetRolePermissions, middleware.RequirePermission("roles:write"))
+	g.GET("/permissions", adminRoleHandler.ListPermissions, middleware.RequirePermission("roles:read"))
+
+	apiKeys := g.Group("/api-keys")
+	apiKeys.GET("", apiKeyHandler.List, middleware.RequirePermission("api_keys:read"))
+	apiKeys.POST("", apiKeyHandler.Create, middleware.RequirePermission("api_keys:write"))
+	apiKeys.GET("/:id", apiKeyHandler.Get, middleware.RequirePermission("api_keys:read"))
+	apiKeys.PATCH("/:id", apiKeyHandler.Update, middleware.RequirePermission("api_keys:write"))
+	apiKeys.POST("/:id/rotate", apiKeyHandler.Rotate, middleware.RequirePermission("api_keys:write"))
+	apiKeys.DELETE("/:id", apiKeyHandler.Revoke, middleware.RequirePermission("api_keys:write"))
+
+	clients := g.Group("/clients")
+	clients.GET("", oauthClientHandler.List, middleware.RequirePermission("clients:read"))
+	clients.POST("", oauthClientHandler.Create, middleware.RequirePermission("clients:write"))
+	clients.GET("/:id", oauthClientHandler.Get, middleware.RequirePermission("clients:read"))
+	clients.PATCH("/:id", oauthClientHandler.Update, middleware.RequirePermission("clients:write"))
+	clients.POST("/:id/rotate", oauthClientHandler.RotateSecret, middleware.RequirePermission("clients:write"))
+	clients.DELETE("/:id", oauthClientHandler.Revoke, middleware.RequirePermission("clients:write"))
+}
@@ -18,11 +18,34 @@ func RegisterAuthRoutes(g *echo.Group, repo port.RepositoryRegistry) {
 	g.POST("/login", authHandler.Login)
 	g.POST("/refresh", authHandler.Refresh)
 	g.POST("/register", authHandler.Register)
+	g.POST("/logout", authHandler.Logout, middleware.AuthBearer)
+	g.POST("/password/forgot", authHandler.ForgotPassword)
+	g.POST("/password/reset", authHandler.ResetPassword)
+	g.POST("/verify-email", authHandler.VerifyEmail)
+	g.POST("/verify-email/resend", authHandler.ResendVerification)
+	g.POST("/magic/request", authHandler.RequestMagicLink)
+	g.POST("/magic/verify", authHandler.VerifyMagicLink)
+	g.POST("/mfa/verify", authHandler.VerifyMfa)
+	g.GET("/oidc/providers", authHandler.OidcProviders)
+	g.POST("/oidc/:provider/authorize", authHandler.OidcAuthorize)
+	g.POST("/oidc/:provider/callback", authHandler.OidcCallback)
 
 	// Protected route
 	protected := g.Group("/me")
 	protected.Use(middleware.AuthBearer)
-	protected.GET("", authHandler.Profile)
+	protected.GET("", authHandler.Profile, middleware.RequireScopes("profile"))
+	protected.PATCH("", authHandler.UpdateProfile, middleware.RequireScopes("profile"))
+	protected.PUT("/password", authHandler.ChangePassword, middleware.RequireScopes("account"))
+	protected.DELETE("", authHandler.DeleteAccount, middleware.RequireScopes("account"))
+	protected.GET("/sessions", authHandler.ListSessions, middleware.RequireScopes("sessions"))
+	protected.DELETE("/sessions", authHandler.RevokeOtherSessions, middleware.RequireScopes("sessions"))
+	protected.DELETE("/sessions/:id", authHandler.RevokeSession, middleware.RequireScopes("sessions"))
+
+	mfa := g.Group("/mfa")
+	mfa.Use(middleware.AuthBearer, middleware.RequireScopes("mfa"))
+	mfa.POST("/enroll", authHandler.EnrollMfa)
+	mfa.POST("/confirm", authHandler.ConfirmMfa)
+	mfa.POST("/disable", authHandler.DisableMfa)
 
 	g.Any("/*", func(c echo.Context) error {
 		log.Info().
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"echo-jwt-starter/config"
+	"echo-jwt-starter/internal/handler"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/internal/service"
+	"echo-jwt-starter/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RegisterOAuthRoutes registers the token (client_credentials), RFC 7662 and RFC 7009 endpoints,
+// authenticated by client credentials instead of x-api-key.
+func RegisterOAuthRoutes(g *echo.Group, repo port.RepositoryRegistry) {
+	authHandler := handler.NewAuthHandler(service.NewAuthService(repo))
+	oauthClientService := service.NewOAuthClientService(repo)
+	oauthClientHandler := handler.NewOAuthClientHandler(oauthClientService)
+
+	// Authenticated clients are rate limited like on /api
+	clientAuth := func(scope string) []echo.MiddlewareFunc {
+		mw := []echo.MiddlewareFunc{middleware.OAuthClientAuth(oauthClientService, scope)}
+		if limit := config.Envs.APIKeys.RateLimitPerMinute; limit > 0 {
+			mw = append(mw, middleware.APIClientRateLimiter(limit))
+		}
+		return mw
+	}
+
+	g.POST("/token", oauthClientHandler.Token, clientAuth("")...)
+	g.POST("/introspect", authHandler.Introspect, clientAuth("oauth:introspect")...)
+	g.POST("/revoke", authHandler.RevokeToken, clientAuth("oauth:revoke")...)
+}
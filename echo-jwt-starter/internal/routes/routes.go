@@ -2,12 +2,13 @@ package routes
 
 import (
 	"echo-jwt-starter/config"
+	"echo-jwt-starter/internal/handler"
 	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/internal/service"
+	"echo-jwt-starter/middleware"
 	"echo-jwt-starter/pkg/response"
-	"github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog/log"
 	"net/http"
-	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -23,23 +24,32 @@ func NewRouteRegistry(repository port.RepositoryRegistry) *RouteRegistry {
 }
 
 func (r *RouteRegistry) RegisterRoutes(e *echo.Echo) {
+	// Public JWKS so other services can verify our tokens (no x-api-key)
+	e.GET("/.well-known/jwks.json", handler.NewJWKSHandler().GetJWKS)
+
+	// Token introspection and revocation for other services (client credentials, no x-api-key)
+	RegisterOAuthRoutes(e.Group("/oauth"), r.Repository)
+
 	api := e.Group("/api")
 
-	api.Use(middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
-		KeyLookup: "header:x-api-key",
-		Validator: func(key string, c echo.Context) (bool, error) {
-			return strings.EqualFold(key, config.Envs.APIKeys.XApiKey), nil
-		},
-		ErrorHandler: func(err error, c echo.Context) error {
-			log.Error().Err(err).Msg("route::SetupRoutes - Invalid x-api-key")
-			return c.JSON(http.StatusUnauthorized, response.Error(err.Error()))
-		},
-	}))
-
-	// Public routes
-	auth := api.Group("/auth")
+	// Resolve the calling API client from x-api-key (api_keys table or legacy X_API_KEY)
+	apiKeyService := service.NewApiKeyService(r.Repository)
+	api.Use(middleware.APIKeyAuth(apiKeyService))
+	if limit := config.Envs.APIKeys.RateLimitPerMinute; limit > 0 {
+		api.Use(middleware.APIClientRateLimiter(limit))
+	}
+
+	// Double-submit CSRF check for browser clients authenticated by cookie (AUTH_COOKIE_ENABLED)
+	api.Use(middleware.CSRF)
+
+	// Public routes, api keys need the auth scope
+	auth := api.Group("/auth", middleware.RequireAPIScope("auth"))
 	RegisterAuthRoutes(auth, r.Repository)
 
+	// Admin routes (Bearer + role admin), api keys need the admin scope
+	admin := api.Group("/admin", middleware.RequireAPIScope("admin"))
+	RegisterAdminRoutes(admin, r.Repository)
+
 	// Contoh protected route:
 	// user := api.Group("/user", middleware.AuthBearer)
 	// RegisterUserRoutes(user, r.UserHandler)
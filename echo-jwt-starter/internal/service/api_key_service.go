@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"echo-jwt-starter/config"
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/apikey"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// legacyClientID identifies requests authenticated with the single X_API_KEY from config.
+const legacyClientID = "legacy"
+
+// lastUsedInterval is how stale last_used_at may get before a request refreshes it.
+const lastUsedInterval = time.Minute
+
+type ApiKeyService interface {
+	apikey.Resolver
+	List(ctx context.Context) ([]dto.ApiKeyResponse, error)
+	Get(ctx context.Context, id string) (dto.ApiKeyResponse, error)
+	Create(ctx context.Context, req dto.ApiKeyCreateRequest) (dto.ApiKeySecretResponse, error)
+	Update(ctx context.Context, id string, req dto.ApiKeyUpdateRequest) (dto.ApiKeyResponse, error)
+	Rotate(ctx context.Context, id string) (dto.ApiKeySecretResponse, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+type ApiKeyServiceImpl struct {
+	cfg        *config.Config
+	repository port.RepositoryRegistry
+}
+
+func NewApiKeyService(repo port.RepositoryRegistry) ApiKeyService {
+	return &ApiKeyServiceImpl{
+		cfg:        config.Envs,
+		repository: repo,
+	}
+}
+
+func (s *ApiKeyServiceImpl) Resolve(ctx context.Context, key string) (*apikey.Client, error) {
+	invalidErr := errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Unauthorized: invalid x-api-key"))
+
+	prefix, ok := apikey.Prefix(key)
+	if !ok {
+		if s.cfg.APIKeys.XApiKey != "" && apikey.Equal(key, s.cfg.APIKeys.XApiKey) {
+			return &apikey.Client{ID: legacyClientID, Name: "X_API_KEY", Scopes: []string{"*"}}, nil
+		}
+		return nil, invalidErr
+	}
+
+	keyRepo := s.repository.GetApiKeyRepository()
+	stored, err := keyRepo.FindByPrefix(ctx, prefix)
+	if err != nil {
+		if errmsg.HasCode(err, http.StatusNotFound) {
+			return nil, invalidErr
+		}
+		return nil, err
+	}
+	if !apikey.Matches(key, stored.KeyHash) {
+		return nil, invalidErr
+	}
+	if stored.RevokedAt != nil || (stored.ExpiresAt != nil && utils.Now().After(*stored.ExpiresAt)) {
+		log.Warn().Str("api_key_id", stored.Id).Msg("service::ApiKey.Resolve - Revoked or expired api key used")
+		return nil, invalidErr
+	}
+
+	// Skip the write while last_used_at is recent, the key is resolved on every request
+	if stored.LastUsedAt == nil || utils.Now().Sub(*stored.LastUsedAt) >= lastUsedInterval {
+		if err = keyRepo.TouchLastUsed(ctx, stored.Id); err != nil {
+			log.Warn().Err(err).Str("api_key_id", stored.Id).Msg("service::ApiKey.Resolve - Failed to record usage")
+		}
+	}
+
+	return &apikey.Client{ID: stored.Id, Name: stored.Name, Scopes: stored.Scopes}, nil
+}
+
+func (s *ApiKeyServiceImpl) List(ctx context.Context) ([]dto.ApiKeyResponse, error) {
+	keys, err := s.repository.GetApiKeyRepository().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.ApiKeyResponse, 0, len(keys))
+	for i := range keys {
+		res = append(res, toApiKeyResponse(&keys[i]))
+	}
+	return res, nil
+}
+
+func (s *ApiKeyServiceImpl) Get(ctx context.Context, id string) (dto.ApiKeyResponse, error) {
+	key, err := s.repository.GetApiKeyRepository().FindById(ctx, id)
+	if err != nil {
+		return dto.ApiKeyResponse{}, err
+	}
+	return toApiKeyResponse(key), nil
+}
+
+func (s *ApiKeyServiceImpl) Create(ctx context.Context, req dto.ApiKeyCreateRequest) (dto.ApiKeySecretResponse, error) {
+	raw, prefix, hash, err := apikey.Generate()
+	if err != nil {
+		return dto.ApiKeySecretResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat api key"))
+	}
+
+	scopes := req.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	key := &entity.ApiKeyDB{
+		Id:        utils.GenerateID(),
+		Name:      req.Name,
+		Owner:     req.Owner,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+	keyRepo := s.repository.GetApiKeyRepository()
+	if err = keyRepo.Create(ctx, key); err != nil {
+		return dto.ApiKeySecretResponse{}, err
+	}
+
+	created, err := keyRepo.FindById(ctx, key.Id)
+	if err != nil {
+		return dto.ApiKeySecretResponse{}, err
+	}
+
+	log.Info().Str("api_key_id", key.Id).Str("name", key.Name).Msg("service::ApiKey.Create - Api key created")
+	return dto.ApiKeySecretResponse{ApiKeyResponse: toApiKeyResponse(created), Key: raw}, nil
+}
+
+func (s *ApiKeyServiceImpl) Update(ctx context.Context, id string, req dto.ApiKeyUpdateRequest) (dto.ApiKeyResponse, error) {
+	keyRepo := s.repository.GetApiKeyRepository()
+	key, err := keyRepo.FindById(ctx, id)
+	if err != nil {
+		return dto.ApiKeyResponse{}, err
+	}
+
+	if req.Name != nil {
+		key.Name = *req.Name
+	}
+	if req.Owner != nil {
+		key.Owner = *req.Owner
+	}
+	if req.Scopes != nil {
+		key.Scopes = *req.Scopes
+	}
+	if req.ExpiresAt != nil {
+		key.ExpiresAt = req.ExpiresAt
+	}
+
+	if err = keyRepo.Update(ctx, key); err != nil {
+		return dto.ApiKeyResponse{}, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *ApiKeyServiceImpl) Rotate(ctx context.Context, id string) (dto.ApiKeySecretResponse, error) {
+	keyRepo := s.repository.GetApiKeyRepository()
+	key, err := keyRepo.FindById(ctx, id)
+	if err != nil {
+		return dto.ApiKeySecretResponse{}, err
+	}
+	if key.RevokedAt != nil {
+		return dto.ApiKeySecretResponse{}, errmsg.NewCustomErrors(http.StatusConflict, errmsg.WithMessage("Api key sudah dicabut"))
+	}
+
+	raw, prefix, hash, err := apikey.Generate()
+	if err != nil {
+		return dto.ApiKeySecretResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat api key"))
+	}
+	if err = keyRepo.Rotate(ctx, id, prefix, hash); err != nil {
+		return dto.ApiKeySecretResponse{}, err
+	}
+
+	rotated, err := keyRepo.FindById(ctx, id)
+	if err != nil {
+		return dto.ApiKeySecretResponse{}, err
+	}
+
+	log.Info().Str("api_key_id", id).Msg("service::ApiKey.Rotate - Api key rotated")
+	return dto.ApiKeySecretResponse{ApiKeyResponse: toApiKeyResponse(rotated), Key: raw}, nil
+}
+
+func (s *ApiKeyServiceImpl) Revoke(ctx context.Context, id string) error {
+	keyRepo := s.repository.GetApiKeyRepository()
+	if _, err := keyRepo.FindById(ctx, id); err != nil {
+		return err
+	}
+
+	log.Info().Str("api_key_id", id).Msg("service::ApiKey.Revoke - Api key revoked")
+	return keyRepo.Revoke(ctx, id)
+}
+
+func toApiKeyResponse(key *entity.ApiKeyDB) dto.ApiKeyResponse {
+	return dto.ApiKeyResponse{
+		ID:         key.Id,
+		Name:       key.Name,
+		Owner:      key.Owner,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"echo-jwt-starter/config"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/loginguard"
+	"echo-jwt-starter/pkg/utils"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ipGuard    *loginguard.Guard
+	emailGuard *loginguard.Guard
+	guardOnce  sync.Once
+)
+
+// loginGuards returns the process wide guards tracking failed attempts per client IP and per email.
+func loginGuards() (*loginguard.Guard, *loginguard.Guard) {
+	guardOnce.Do(func() {
+		cfg := config.Envs.Auth
+		base := time.Duration(cfg.LockoutBaseSeconds) * time.Second
+		max := time.Duration(cfg.LockoutMaxMinutes) * time.Minute
+		ipGuard = loginguard.New(cfg.IPLockoutThreshold, base, max, time.Minute)
+		emailGuard = loginguard.New(cfg.LockoutThreshold, base, max, time.Minute)
+	})
+	return ipGuard, emailGuard
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func emailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func mfaKey(userId string) string {
+	return "mfa:" + userId
+}
+
+// Semua kegagalan login memakai pesan yang sama agar tidak membocorkan email yang terdaftar
+func invalidCredentialsErr() error {
+	return errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage(errmsg.InvalidCredentials))
+}
+
+func tooManyAttemptsErr() error {
+	return errmsg.NewCustomErrors(http.StatusTooManyRequests, errmsg.WithMessage("Terlalu banyak percobaan login, silakan coba lagi nanti"))
+}
+
+// checkLoginAllowed rejects attempts from a locked client IP or for a locked email.
+func (s *AuthServiceImpl) checkLoginAllowed(ip, email string) error {
+	ips, emails := loginGuards()
+	now := utils.Now()
+	if (ip != "" && ips.LockedFor(ipKey(ip), now) > 0) || emails.LockedFor(emailKey(email), now) > 0 {
+		return tooManyAttemptsErr()
+	}
+	return nil
+}
+
+// recordFailedLogin counts the failure for the client IP, the email and, when it exists, the account,
+// locking the account with exponential backoff once the threshold is reached.
+func (s *AuthServiceImpl) recordFailedLogin(ctx context.Context, ip, email string, user *entity.UserDB) {
+	ips, emails := loginGuards()
+	now := utils.Now()
+	if ip != "" {
+		ips.Fail(ipKey(ip), now)
+	}
+	emails.Fail(emailKey(email), now)
+
+	if user == nil {
+		return
+	}
+
+	userRepo := s.repository.GetUserRepository()
+	count, err := userRepo.IncrementFailedLogins(ctx, user.Id)
+	if err != nil {
+		return
+	}
+
+	lock := loginguard.Backoff(count, s.cfg.Auth.LockoutThreshold,
+		time.Duration(s.cfg.Auth.LockoutBaseSeconds)*time.Second,
+		time.Duration(s.cfg.Auth.LockoutMaxMinutes)*time.Minute)
+	if lock == 0 {
+		return
+	}
+
+	log.Warn().
+		Str("user_id", user.Id).
+		Int("failed_login_count", count).
+		Dur("locked_for", lock).
+		Msg("service::Login - Account locked after repeated failed logins")
+	_ = userRepo.LockUntil(ctx, user.Id, now.Add(lock))
+}
+
+// recordSuccessfulLogin clears the failure counters of the client IP, the email and the account, so the
+// thresholds count consecutive failures.
+func (s *AuthServiceImpl) recordSuccessfulLogin(ctx context.Context, ip, email string, user *entity.UserDB) error {
+	ips, emails := loginGuards()
+	if ip != "" {
+		ips.Reset(ipKey(ip))
+	}
+	emails.Reset(emailKey(email))
+
+	if user.FailedLoginCount == 0 && user.LockedUntil == nil {
+		return nil
+	}
+	return s.repository.GetUserRepository().ResetFailedLogins(ctx, user.Id)
+}
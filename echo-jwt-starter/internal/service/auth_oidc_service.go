@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/oidc"
+	"echo-jwt-starter/pkg/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *AuthServiceImpl) OidcProviders() []string {
+	return s.oidc.Names()
+}
+
+func (s *AuthServiceImpl) OidcAuthorize(ctx context.Context, provider string) (dto.OidcAuthorizeResponse, error) {
+	p, ok := s.oidc.Get(provider)
+	if !ok {
+		return dto.OidcAuthorizeResponse{}, errmsg.NewCustomErrors(http.StatusNotFound, errmsg.WithMessage("Provider tidak ditemukan"))
+	}
+
+	state, err := oidc.GenerateVerifier()
+	if err != nil {
+		return dto.OidcAuthorizeResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat state OIDC"))
+	}
+	nonce, err := oidc.GenerateVerifier()
+	if err != nil {
+		return dto.OidcAuthorizeResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat state OIDC"))
+	}
+	verifier, err := oidc.GenerateVerifier()
+	if err != nil {
+		return dto.OidcAuthorizeResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat state OIDC"))
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		log.Error().Err(err).Str("provider", provider).Msg("service::OidcAuthorize - Failed to build authorization url")
+		return dto.OidcAuthorizeResponse{}, errmsg.NewCustomErrors(http.StatusBadGateway, errmsg.WithMessage("Provider OIDC tidak dapat dihubungi"))
+	}
+
+	if err = s.repository.GetOidcStateRepository().Create(ctx, &entity.OidcStateDB{
+		StateHash:    utils.HashToken(state),
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    utils.Now().Add(time.Duration(s.cfg.Oidc.StateTtlMinutes) * time.Minute),
+	}); err != nil {
+		return dto.OidcAuthorizeResponse{}, err
+	}
+
+	return dto.OidcAuthorizeResponse{
+		AuthorizationURL: authURL,
+		State:            state,
+	}, nil
+}
+
+func (s *AuthServiceImpl) OidcCallback(ctx context.Context, provider string, req dto.OidcCallbackRequest) (dto.LoginResponse, error) {
+	invalidErr := errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("State OIDC tidak valid atau sudah kedaluwarsa"))
+
+	p, ok := s.oidc.Get(provider)
+	if !ok {
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(http.StatusNotFound, errmsg.WithMessage("Provider tidak ditemukan"))
+	}
+
+	state, err := s.repository.GetOidcStateRepository().Consume(ctx, utils.HashToken(req.State))
+	if err != nil {
+		return dto.LoginResponse{}, invalidErr
+	}
+	if state.Provider != provider || utils.Now().After(state.ExpiresAt) {
+		return dto.LoginResponse{}, invalidErr
+	}
+
+	token, err := p.Exchange(ctx, req.Code, state.CodeVerifier)
+	if err != nil {
+		log.Warn().Err(err).Str("provider", provider).Msg("service::OidcCallback - Code exchange failed")
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Login OIDC gagal"))
+	}
+
+	claims, err := p.VerifyIDToken(ctx, token.IDToken, state.Nonce)
+	if err != nil {
+		log.Warn().Err(err).Str("provider", provider).Msg("service::OidcCallback - ID token rejected")
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Login OIDC gagal"))
+	}
+
+	out, err := s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		return s.resolveOidcUser(ctx, repo, provider, claims)
+	})
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	return s.completeLogin(ctx, out.(*entity.UserDB), "", req.ClientInfo)
+}
+
+// resolveOidcUser returns the user linked to the external identity. Unknown identities are linked to
+// the user with the same provider-verified email, or get a new user when auto registration is enabled.
+func (s *AuthServiceImpl) resolveOidcUser(ctx context.Context, repo port.RepositoryRegistry, provider string, claims *oidc.IDTokenClaims) (*entity.UserDB, error) {
+	identityRepo := repo.GetUserIdentityRepository()
+	userRepo := repo.GetUserRepository()
+
+	identity, err := identityRepo.FindByProviderSubject(ctx, provider, claims.Subject)
+	if err == nil {
+		if err = identityRepo.TouchLastLogin(ctx, identity.Id); err != nil {
+			return nil, err
+		}
+		return userRepo.FindById(ctx, identity.UserId)
+	}
+	if !errmsg.HasCode(err, http.StatusNotFound) {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	if email == "" {
+		return nil, errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("Provider tidak mengirimkan email, pastikan scope email diizinkan"))
+	}
+	verified := bool(claims.EmailVerified)
+
+	user, err := userRepo.FindByEmail(ctx, email)
+	switch {
+	case err == nil:
+		// Menautkan ke akun yang sudah ada hanya jika provider menjamin kepemilikan email
+		if !s.cfg.Oidc.LinkByEmail || !verified {
+			return nil, errmsg.NewCustomErrors(http.StatusConflict, errmsg.WithMessage("Email sudah terdaftar, silakan login dengan password"))
+		}
+	case errmsg.HasCode(err, http.StatusNotFound):
+		if !s.cfg.Oidc.AutoRegister {
+			return nil, errmsg.NewCustomErrors(http.StatusForbidden, errmsg.WithMessage("Akun belum terdaftar"))
+		}
+		if user, err = s.createOidcUser(ctx, repo, email); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if verified && user.EmailVerifiedAt == nil {
+		if err = userRepo.MarkEmailVerified(ctx, user.Id); err != nil {
+			return nil, err
+		}
+		now := utils.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	if err = identityRepo.Create(ctx, &entity.UserIdentityDB{
+		Id:       utils.GenerateID(),
+		UserId:   user.Id,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    email,
+	}); err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("user_id", user.Id).Str("provider", provider).Msg("service::OidcCallback - External identity linked")
+	return user, nil
+}
+
+// createOidcUser registers a user with an unusable random password, the user can set one via forgot password.
+func (s *AuthServiceImpl) createOidcUser(ctx context.Context, repo port.RepositoryRegistry, email string) (*entity.UserDB, error) {
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat user"))
+	}
+	hashedPassword, err := s.passwords.Hash(randomPassword)
+	if err != nil {
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengenkripsi password"))
+	}
+
+	user := &entity.UserDB{
+		Id:       utils.GenerateID(),
+		Email:    email,
+		Password: hashedPassword,
+		Role:     s.cfg.Auth.DefaultRole,
+	}
+	if err = repo.GetUserRepository().Create(ctx, user); err != nil {
+		return nil, err
+	}
+	if err = grantRole(ctx, repo, user.Id, user.Role, ""); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/password"
+	"echo-jwt-starter/pkg/utils"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// checkNewPassword enforces the password policy and the breached password list, and for existing
+// users (userId set) refuses the last PASSWORD_HISTORY_SIZE passwords.
+func (s *AuthServiceImpl) checkNewPassword(ctx context.Context, plain, email, userId string) error {
+	err := errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("Password tidak memenuhi kebijakan password"))
+
+	localPart, _, _ := strings.Cut(email, "@")
+	for _, problem := range s.policy.Check(plain, localPart) {
+		err.Add("password", problem)
+	}
+	breached, berr := password.IsBreached(plain)
+	if berr != nil {
+		return berr
+	}
+	if breached {
+		err.Add("password", "password ini ditemukan pada daftar password yang pernah bocor, gunakan password lain")
+	}
+	if err.HasErrors() {
+		return err
+	}
+
+	size := s.cfg.Password.HistorySize
+	if userId == "" || size <= 0 {
+		return nil
+	}
+
+	// Checked last, every comparison costs a full password hash
+	hashes, herr := s.repository.GetPasswordHistoryRepository().ListRecentHashes(ctx, userId, size)
+	if herr != nil {
+		return herr
+	}
+	for _, hash := range hashes {
+		if s.passwords.Verify(hash, plain) == nil {
+			err.Add("password", fmt.Sprintf("password tidak boleh sama dengan %d password terakhir", size))
+			return err
+		}
+	}
+	return nil
+}
+
+// recordPassword appends the new hash to the password history and drops entries beyond the configured size.
+func (s *AuthServiceImpl) recordPassword(ctx context.Context, repo port.RepositoryRegistry, userId, hashedPassword string) error {
+	historyRepo := repo.GetPasswordHistoryRepository()
+	if err := historyRepo.Create(ctx, &entity.PasswordHistoryDB{
+		Id:           utils.GenerateID(),
+		UserId:       userId,
+		PasswordHash: hashedPassword,
+	}); err != nil {
+		return err
+	}
+	// Keep at least the current password so enabling the history later has a starting point
+	return historyRepo.Prune(ctx, userId, max(s.cfg.Password.HistorySize, 1))
+}
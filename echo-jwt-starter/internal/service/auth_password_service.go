@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/notifier"
+	"echo-jwt-starter/pkg/utils"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *AuthServiceImpl) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	// Selalu sukses agar tidak membocorkan email yang terdaftar
+	user, err := s.repository.GetUserRepository().FindByEmail(ctx, req.Email)
+	if err != nil {
+		log.Info().Err(err).Msg("service::ForgotPassword - User not found, skipping")
+		return nil
+	}
+
+	// Throttle dan kegagalan kirim hanya dicatat, respons sama dengan email yang tidak terdaftar
+	if err = s.checkTokenThrottle(ctx, user.Id, entity.TokenPurposePasswordReset); err == nil {
+		err = s.sendPasswordReset(ctx, user)
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", user.Id).Msg("service::ForgotPassword - Reset link not sent")
+	}
+	return nil
+}
+
+// sendPasswordReset issues a new password reset token and emails the link to the user.
+func (s *AuthServiceImpl) sendPasswordReset(ctx context.Context, user *entity.UserDB) error {
+	ttl := time.Duration(s.cfg.Auth.PasswordResetTtlMinutes) * time.Minute
+	token, err := s.issueUserToken(ctx, user.Id, entity.TokenPurposePasswordReset, ttl)
+	if err != nil {
+		return err
+	}
+
+	link, err := withTokenParam(s.cfg.Auth.PasswordResetURL, token)
+	if err != nil {
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Konfigurasi PASSWORD_RESET_URL tidak valid"))
+	}
+
+	if err = s.notifier.Send(ctx, notifier.Message{
+		To:      user.Email,
+		Subject: "Reset password",
+		Body: fmt.Sprintf("Gunakan link berikut untuk mengatur ulang password Anda:\n\n%s\n\nLink berlaku selama %d menit dan hanya dapat digunakan sekali. Abaikan email ini jika Anda tidak meminta reset password.",
+			link, s.cfg.Auth.PasswordResetTtlMinutes),
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.Id).Msg("service::sendPasswordReset - Failed to send reset link")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengirim link reset password"))
+	}
+
+	return nil
+}
+
+func (s *AuthServiceImpl) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	invalidErr := errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("Token reset password tidak valid atau sudah kedaluwarsa"))
+
+	// Token dan password baru dicek lebih dulu, token baru dipakai di dalam transaksi
+	token, err := s.repository.GetUserTokenRepository().FindByHash(ctx, entity.TokenPurposePasswordReset, utils.HashToken(req.Token))
+	if err != nil {
+		return invalidErr
+	}
+	if token.UsedAt != nil || utils.Now().After(token.ExpiresAt) {
+		return invalidErr
+	}
+
+	user, err := s.repository.GetUserRepository().FindById(ctx, token.UserId)
+	if err != nil {
+		return invalidErr
+	}
+	if err = s.checkNewPassword(ctx, req.Password, user.Email, user.Id); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwords.Hash(req.Password)
+	if err != nil {
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengenkripsi password"))
+	}
+
+	_, err = s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		if _, err := s.consumeUserToken(ctx, repo, entity.TokenPurposePasswordReset, req.Token, invalidErr); err != nil {
+			return nil, err
+		}
+
+		if err := repo.GetUserRepository().UpdatePassword(ctx, user.Id, hashedPassword); err != nil {
+			return nil, err
+		}
+		if err := s.recordPassword(ctx, repo, user.Id, hashedPassword); err != nil {
+			return nil, err
+		}
+
+		// Paksa login ulang di semua perangkat
+		return nil, repo.GetRefreshTokenRepository().RevokeByUser(ctx, user.Id)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Akhiri semua sesi, termasuk access token yang sudah diterbitkan
+	families, err := s.repository.GetSessionRepository().RevokeByUser(ctx, user.Id, "")
+	if err != nil {
+		return err
+	}
+	return s.revokeFamilies(ctx, families...)
+}
+
+// withTokenParam appends the token as ?token= query parameter to a configured link.
+func withTokenParam(rawURL, token string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// rehashPassword replaces the stored hash when it no longer matches the preferred algorithm or settings.
+// Failures are only logged, the user is already authenticated and the upgrade is retried on the next login.
+func (s *AuthServiceImpl) rehashPassword(ctx context.Context, user *entity.UserDB, plain string) {
+	if !s.passwords.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := s.passwords.Hash(plain)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", user.Id).Msg("service::rehashPassword - Failed to hash password")
+		return
+	}
+	if err = s.repository.GetUserRepository().UpdatePassword(ctx, user.Id, hashedPassword); err != nil {
+		log.Error().Err(err).Str("user_id", user.Id).Msg("service::rehashPassword - Failed to store upgraded hash")
+		return
+	}
+	user.Password = hashedPassword
+	log.Info().Str("user_id", user.Id).Msg("service::rehashPassword - Password hash upgraded")
+}
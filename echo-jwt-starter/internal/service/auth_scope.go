@@ -0,0 +1,56 @@
+package service
+
+import (
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/jwthandler"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// resolveScope validates the scope requested for new user tokens and returns the scope claim to issue,
+// empty for unrestricted tokens. Scopes must be listed in AUTH_SCOPES (any scope when empty) and, when
+// derived from parent (refresh), granted by it so a token can only ever be narrowed.
+func (s *AuthServiceImpl) resolveScope(requested string, parent *jwthandler.CustomClaims) (string, error) {
+	scopes := jwthandler.ParseScope(requested)
+	if len(scopes) == 0 {
+		if parent != nil {
+			return parent.Scope, nil
+		}
+		return "", nil
+	}
+
+	// "*" is the same as not limiting the token
+	if slices.Contains(scopes, jwthandler.AllScopes) {
+		scopes = []string{jwthandler.AllScopes}
+	}
+
+	known := s.knownScopes()
+	for _, scope := range scopes {
+		if scope != jwthandler.AllScopes && len(known) > 0 && !slices.Contains(known, scope) {
+			return "", errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithErrors("scope", "Scope "+scope+" tidak dikenal"))
+		}
+		if parent != nil && !parent.HasScope(scope) {
+			return "", errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithErrors("scope", "Scope "+scope+" melebihi scope refresh token"))
+		}
+	}
+
+	if scopes[0] == jwthandler.AllScopes {
+		if parent != nil {
+			return parent.Scope, nil
+		}
+		return "", nil
+	}
+	return strings.Join(scopes, " "), nil
+}
+
+// knownScopes returns the scopes configured in AUTH_SCOPES.
+func (s *AuthServiceImpl) knownScopes() []string {
+	var scopes []string
+	for _, scope := range strings.Split(s.cfg.Auth.Scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"echo-jwt-starter/config"
+	"echo-jwt-starter/internal/dto"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/jwthandler"
+	"echo-jwt-starter/pkg/revocation"
+	"echo-jwt-starter/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxUserAgentLength keeps oversized User-Agent headers out of the sessions table.
+const maxUserAgentLength = 512
+
+// startSession issues the token pair of a new login and records the session (device) it belongs to.
+// The session is identified by the refresh token family, refreshed tokens stay in the same session.
+func (s *AuthServiceImpl) startSession(ctx context.Context, userId, role string, mfa bool, scope string, client dto.ClientInfo) (dto.LoginResponse, error) {
+	familyId := utils.GenerateID()
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	out, err := s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		if err := repo.GetSessionRepository().Create(ctx, &entity.SessionDB{
+			Id:        utils.GenerateID(),
+			UserId:    userId,
+			FamilyId:  familyId,
+			UserAgent: userAgent,
+			IP:        client.IP,
+		}); err != nil {
+			return nil, err
+		}
+		return s.issueTokens(ctx, repo, userId, role, familyId, mfa, scope)
+	})
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+	return out.(dto.LoginResponse), nil
+}
+
+// revokeFamilies ends the given sessions: their refresh tokens stop working and access tokens
+// already issued to them are rejected by AuthBearer. The family stays revoked for the refresh token
+// lifetime so no token of the session outlives the revocation.
+func (s *AuthServiceImpl) revokeFamilies(ctx context.Context, familyIds ...string) error {
+	return revokeSessionFamilies(ctx, s.repository, s.cfg, familyIds...)
+}
+
+func revokeSessionFamilies(ctx context.Context, repo port.RepositoryRegistry, cfg *config.Config, familyIds ...string) error {
+	familyExpiry := utils.Now().Add(time.Duration(cfg.Guard.JwtRefreshTtlDays) * 24 * time.Hour)
+	for _, familyId := range familyIds {
+		if err := repo.GetRefreshTokenRepository().RevokeFamily(ctx, familyId); err != nil {
+			return err
+		}
+		if err := repo.GetSessionRepository().RevokeByFamily(ctx, familyId); err != nil {
+			return err
+		}
+		if err := revocation.Revoke(ctx, revocation.FamilyKey(familyId), familyExpiry); err != nil {
+			log.Error().Err(err).Str("family_id", familyId).Msg("service::revokeFamilies - Failed to revoke session access tokens")
+			return errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mencabut sesi"))
+		}
+	}
+	return nil
+}
+
+// revokeUserSessions ends every session of the user, used when an admin disables or deletes the account.
+func revokeUserSessions(ctx context.Context, repo port.RepositoryRegistry, cfg *config.Config, userId string) error {
+	if err := repo.GetRefreshTokenRepository().RevokeByUser(ctx, userId); err != nil {
+		return err
+	}
+	families, err := repo.GetSessionRepository().RevokeByUser(ctx, userId, "")
+	if err != nil {
+		return err
+	}
+	return revokeSessionFamilies(ctx, repo, cfg, families...)
+}
+
+func (s *AuthServiceImpl) ListSessions(ctx context.Context, claims *jwthandler.CustomClaims) ([]dto.SessionResponse, error) {
+	// sessions idle longer than the refresh token lifetime cannot be resumed anymore
+	since := utils.Now().Add(-time.Duration(s.cfg.Guard.JwtRefreshTtlDays) * 24 * time.Hour)
+	sessions, err := s.repository.GetSessionRepository().ListActiveByUser(ctx, claims.ID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		res = append(res, dto.SessionResponse{
+			ID:         session.Id,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.FamilyId == claims.FamilyID,
+		})
+	}
+	return res, nil
+}
+
+func (s *AuthServiceImpl) RevokeSession(ctx context.Context, claims *jwthandler.CustomClaims, id string) error {
+	notFoundErr := errmsg.NewCustomErrors(http.StatusNotFound, errmsg.WithMessage("Sesi tidak ditemukan"))
+
+	session, err := s.repository.GetSessionRepository().FindById(ctx, id)
+	if err != nil {
+		if errmsg.HasCode(err, http.StatusNotFound) {
+			return notFoundErr
+		}
+		return err
+	}
+	// sessions of other users are reported as missing
+	if session.UserId != claims.ID || session.RevokedAt != nil {
+		return notFoundErr
+	}
+
+	if err = s.revokeFamilies(ctx, session.FamilyId); err != nil {
+		return err
+	}
+
+	log.Info().Str("user_id", claims.ID).Str("session_id", session.Id).Msg("service::RevokeSession - Session revoked")
+	return nil
+}
+
+func (s *AuthServiceImpl) RevokeOtherSessions(ctx context.Context, claims *jwthandler.CustomClaims) (dto.RevokedSessionsResponse, error) {
+	families, err := s.repository.GetSessionRepository().RevokeByUser(ctx, claims.ID, claims.FamilyID)
+	if err != nil {
+		return dto.RevokedSessionsResponse{}, err
+	}
+	if err = s.revokeFamilies(ctx, families...); err != nil {
+		return dto.RevokedSessionsResponse{}, err
+	}
+	// also covers refresh tokens issued before sessions were recorded
+	if err = s.repository.GetRefreshTokenRepository().RevokeOtherFamilies(ctx, claims.ID, claims.FamilyID); err != nil {
+		return dto.RevokedSessionsResponse{}, err
+	}
+
+	log.Info().Str("user_id", claims.ID).Int("revoked", len(families)).Msg("service::RevokeOtherSessions - Other sessions revoked")
+	return dto.RevokedSessionsResponse{Revoked: len(families)}, nil
+}
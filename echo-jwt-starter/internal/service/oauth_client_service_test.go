@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"echo-jwt-starter/internal/entity"
+	"echo-jwt-starter/internal/repository/port"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/utils"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry only serves the repositories a test sets, every other getter panics on the nil interface.
+type fakeRegistry struct {
+	port.RepositoryRegistry
+	clients port.ClientRepository
+	users   port.UserRepository
+}
+
+func (r *fakeRegistry) GetClientRepository() port.ClientRepository {
+	return r.clients
+}
+
+func (r *fakeRegistry) GetUserRepository() port.UserRepository {
+	return r.users
+}
+
+type fakeClientRepo struct {
+	port.ClientRepository
+	clients map[string]*entity.ClientDB
+}
+
+func (r *fakeClientRepo) FindById(_ context.Context, id string) (*entity.ClientDB, error) {
+	if client, ok := r.clients[id]; ok {
+		return client, nil
+	}
+	return nil, errmsg.NewCustomErrors(http.StatusNotFound, errmsg.WithMessage("Client not found"))
+}
+
+func (r *fakeClientRepo) TouchLastUsed(context.Context, string) error {
+	return nil
+}
+
+func TestOAuthClientAuthenticate(t *testing.T) {
+	revokedAt := time.Now()
+	clients := &fakeClientRepo{clients: map[string]*entity.ClientDB{
+		"0b7c6a4e-7c55-4d3c-9b0e-3f5f3c1d2a10": {Id: "0b7c6a4e-7c55-4d3c-9b0e-3f5f3c1d2a10", Name: "reports", SecretHash: utils.HashToken("secret"), Scopes: []string{"reports:read"}},
+		"5d1e2f3a-4b5c-4d6e-8f70-8192a3b4c5d6": {Id: "5d1e2f3a-4b5c-4d6e-8f70-8192a3b4c5d6", SecretHash: utils.HashToken("secret"), RevokedAt: &revokedAt},
+	}}
+	s := &OAuthClientServiceImpl{repository: &fakeRegistry{clients: clients}}
+	ctx := context.Background()
+
+	client, err := s.Authenticate(ctx, "0b7c6a4e-7c55-4d3c-9b0e-3f5f3c1d2a10", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "reports", client.Name)
+	assert.Equal(t, []string{"reports:read"}, client.Scopes)
+
+	for name, creds := range map[string][2]string{
+		"wrong secret":      {"0b7c6a4e-7c55-4d3c-9b0e-3f5f3c1d2a10", "other"},
+		"revoked client":    {"5d1e2f3a-4b5c-4d6e-8f70-8192a3b4c5d6", "secret"},
+		"unknown client":    {"9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a", "secret"},
+		"legacy api key id": {legacyClientID, "secret"},
+	} {
+		_, err = s.Authenticate(ctx, creds[0], creds[1])
+		assert.True(t, errmsg.HasCode(err, http.StatusUnauthorized), name)
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"echo-jwt-starter/pkg/apikey"
+	"echo-jwt-starter/pkg/errmsg"
+	"echo-jwt-starter/pkg/response"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+// APIKeyAuth resolves the x-api-key header to the calling API client and stores it in the context.
+func APIKeyAuth(resolver apikey.Resolver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get("x-api-key")
+			if key == "" {
+				log.Warn().Str("ip", c.RealIP()).Msg("middleware::APIKeyAuth - Missing x-api-key")
+				return c.JSON(http.StatusUnauthorized, response.Error("Unauthorized: missing x-api-key"))
+			}
+
+			client, err := resolver.Resolve(c.Request().Context(), key)
+			if err != nil {
+				log.Warn().Err(err).Str("ip", c.RealIP()).Msg("middleware::APIKeyAuth - Invalid x-api-key")
+				code, errs := errmsg.Errors[any](err)
+				return c.JSON(code, response.Error(errs))
+			}
+
+			log.Debug().
+				Str("api_client_id", client.ID).
+				Str("api_client", client.Name).
+				Str("path", c.Request().URL.Path).
+				Msg("middleware::APIKeyAuth - api client resolved")
+
+			c.Set("api_client", client)
+			return next(c)
+		}
+	}
+}
+
+// GetAPIClientFromContext mengambil API client yang memanggil dari context Echo
+func GetAPIClientFromContext(c echo.Context) *apikey.Client {
+	client, _ := c.Get("api_client").(*apikey.Client)
+	return client
+}
+
+// RequireAPIScope only lets API clients through that were granted every scope, used after APIKeyAuth
+// to limit an API key to the route groups it is meant for.
+func RequireAPIScope(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			client := GetAPIClientFromContext(c)
+			if client == nil {
+				return c.JSON(http.StatusForbidden, response.Error("Forbidden: api client not found"))
+			}
+
+			for _, scope := range scopes {
+				if !client.HasScope(scope) {
+					log.Warn().
+						Str("api_client_id", client.ID).
+						Str("scope", scope).
+						Strs("granted", client.Scopes).
+						Msg("middleware::RequireAPIScope - Api key lacks scope")
+					return c.JSON(http.StatusForbidden, response.Error("Forbidden: api key lacks scope "+scope))
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// APIClientRateLimiter limits the requests per minute of every API client resolved by APIKeyAuth.
+func APIClientRateLimiter(perMinute int) echo.MiddlewareFunc {
+	return echomiddleware.RateLimiterWithConfig(echomiddleware.RateLimiterConfig{
+		Store: echomiddleware.NewRateLimiterMemoryStoreWithConfig(echomiddleware.RateLimiterMemoryStoreConfig{
+			Rate:      rate.Limit(float64(perMinute) / 60),
+			Burst:     perMinute,
+			ExpiresIn: 3 * time.Minute,
+		}),
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			if client := GetAPIClientFromContext(c); client != nil {
+				return "client:" + client.ID, nil
+			}
+			return "ip:" + c.RealIP(), nil
+		},
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			log.Warn().Str("identifier", identifier).Msg("middleware::APIClientRateLimiter - Rate limit exceeded")
+			return c.JSON(http.StatusTooManyRequests, response.Error("Too many requests"))
+		},
+	})
+}
@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"echo-jwt-starter/config"
+	"echo-jwt-starter/pkg/authcookie"
 	"echo-jwt-starter/pkg/jwthandler"
+	"echo-jwt-starter/pkg/revocation"
 	"net/http"
 	"strings"
 
@@ -9,11 +12,11 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// AuthBearer adalah middleware untuk validasi JWT Bearer token
+// AuthBearer adalah middleware untuk validasi JWT Bearer token, di cookie mode access token juga dibaca dari cookie
 func AuthBearer(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		authHeader := c.Request().Header.Get("Authorization")
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+		tokenString := bearerToken(c)
+		if tokenString == "" {
 			log.Warn().Msg("middleware::AuthBearer - missing or invalid Authorization header")
 			return c.JSON(http.StatusUnauthorized, map[string]any{
 				"message": "Unauthorized",
@@ -21,8 +24,6 @@ func AuthBearer(next echo.HandlerFunc) echo.HandlerFunc {
 			})
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
 		claims, err := jwthandler.ParseToken(tokenString)
 		if err != nil {
 			log.Error().
@@ -35,6 +36,36 @@ func AuthBearer(next echo.HandlerFunc) echo.HandlerFunc {
 			})
 		}
 
+		// only access and client tokens are bearer credentials, refresh tokens outlive the session
+		// revocation and mfa_pending tokens are only accepted by the second login step
+		if !bearerSubject(claims.Subject) {
+			log.Warn().
+				Str("user_id", claims.ID).
+				Str("subject", claims.Subject).
+				Msg("middleware::AuthBearer - token subject not accepted as bearer")
+			return c.JSON(http.StatusUnauthorized, map[string]any{
+				"message": "Unauthorized",
+				"success": false,
+			})
+		}
+
+		// the token itself (logout) or its whole session (remote sign-out) may be revoked
+		revoked, err := revocation.IsRevoked(c.Request().Context(), claims.JTI())
+		if err == nil && !revoked && claims.FamilyID != "" {
+			revoked, err = revocation.IsRevoked(c.Request().Context(), revocation.FamilyKey(claims.FamilyID))
+		}
+		if err != nil || revoked {
+			log.Warn().
+				Err(err).
+				Str("user_id", claims.ID).
+				Str("jti", claims.JTI()).
+				Msg("middleware::AuthBearer - token has been revoked")
+			return c.JSON(http.StatusUnauthorized, map[string]any{
+				"message": "Unauthorized",
+				"success": false,
+			})
+		}
+
 		log.Debug().
 			Str("user_id", claims.ID).
 			Str("role", claims.Role).
@@ -43,19 +74,55 @@ func AuthBearer(next echo.HandlerFunc) echo.HandlerFunc {
 
 		c.Set("user_id", claims.ID)
 		c.Set("role", claims.Role)
+		c.Set("client_id", claims.ClientID) // client tokens carry no user_id and role
+		c.Set("claims", claims)
 
 		return next(c)
 	}
 }
 
+// bearerSubject reports whether a token of the subject may authenticate a request.
+func bearerSubject(subject string) bool {
+	switch jwthandler.TokenType(subject) {
+	case jwthandler.AccessToken, jwthandler.ClientToken:
+		return true
+	}
+	return false
+}
+
+// bearerToken returns the token of the Authorization header, falling back to the access cookie in cookie mode.
+func bearerToken(c echo.Context) string {
+	if authHeader := c.Request().Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	if authcookie.Enabled() {
+		if cookie, err := c.Cookie(config.Envs.Cookie.AccessName); err == nil {
+			return cookie.Value
+		}
+	}
+	return ""
+}
+
 // GetUserIDFromContext mengambil user ID dari context Echo
 func GetUserIDFromContext(c echo.Context) string {
 	id, _ := c.Get("user_id").(string)
 	return id
 }
 
+// GetClientIDFromContext mengambil client ID dari context Echo, hanya terisi untuk client token
+func GetClientIDFromContext(c echo.Context) string {
+	id, _ := c.Get("client_id").(string)
+	return id
+}
+
 // GetRoleFromContext mengambil user role dari context Echo
 func GetRoleFromContext(c echo.Context) string {
 	role, _ := c.Get("role").(string)
 	return role
 }
+
+// GetClaimsFromContext mengambil JWT claims dari context Echo
+func GetClaimsFromContext(c echo.Context) *jwthandler.CustomClaims {
+	claims, _ := c.Get("claims").(*jwthandler.CustomClaims)
+	return claims
+}
@@ -1,9 +1,13 @@
 package middleware
 
 import (
+	"echo-jwt-starter/pkg/authz"
+	"echo-jwt-starter/pkg/rbac"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
 	"net/http"
+	"slices"
+	"strings"
 )
 
 func AuthRole(authorizedRoles []string) echo.MiddlewareFunc {
@@ -24,6 +28,15 @@ func AuthRole(authorizedRoles []string) echo.MiddlewareFunc {
 			}
 
 			if _, found := allowed[role]; found {
+				if MfaRequired(role) {
+					if claims := GetClaimsFromContext(c); claims == nil || !claims.MFA {
+						log.Warn().Str("role", role).Msg("middleware::AuthRole - MFA required for role")
+						return c.JSON(http.StatusForbidden, map[string]any{
+							"message": "Terlarang: role anda wajib login menggunakan MFA",
+							"success": false,
+						})
+					}
+				}
 				return next(c)
 			}
 
@@ -39,3 +52,117 @@ func AuthRole(authorizedRoles []string) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// RequirePermission allows the request when the roles granted to the user (user_roles) carry every
+// given permission. Grants are resolved at request time through rbac.UserGrants, so changes apply
+// without re-login. Must run after AuthBearer.
+func RequirePermission(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userId := GetUserIDFromContext(c)
+			if userId == "" {
+				return c.JSON(http.StatusForbidden, map[string]any{
+					"message": "Terlarang: user tidak ditemukan",
+					"success": false,
+				})
+			}
+
+			grants, err := rbac.UserGrants(c.Request().Context(), userId)
+			if err != nil {
+				log.Error().Err(err).Str("user_id", userId).Msg("middleware::RequirePermission - Failed to resolve permissions")
+				return c.JSON(http.StatusInternalServerError, map[string]any{
+					"message": "Gagal memuat hak akses",
+					"success": false,
+				})
+			}
+
+			for _, role := range grants.Roles {
+				if MfaRequired(role) {
+					if claims := GetClaimsFromContext(c); claims == nil || !claims.MFA {
+						log.Warn().Str("user_id", userId).Str("role", role).Msg("middleware::RequirePermission - MFA required for role")
+						return c.JSON(http.StatusForbidden, map[string]any{
+							"message": "Terlarang: role anda wajib login menggunakan MFA",
+							"success": false,
+						})
+					}
+				}
+			}
+
+			for _, permission := range permissions {
+				if !grants.Has(permission) {
+					log.Warn().Any("payload", map[string]any{
+						"user_id":    userId,
+						"permission": permission,
+						"roles":      grants.Roles,
+					}).Msg("middleware::RequirePermission - Forbidden")
+
+					return c.JSON(http.StatusForbidden, map[string]any{
+						"message": "Terlarang: anda tidak memiliki izin " + permission,
+						"success": false,
+					})
+				}
+			}
+
+			c.Set("permissions", grants.Permissions)
+			return next(c)
+		}
+	}
+}
+
+// RequireScopes allows the request when the bearer token grants every given scope ("*" grants all).
+// User tokens issued without a scope are unrestricted, down-scoped user tokens and client tokens must
+// carry the scopes. Must run after AuthBearer.
+func RequireScopes(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims := GetClaimsFromContext(c)
+			if claims == nil {
+				return c.JSON(http.StatusForbidden, map[string]any{
+					"message": "Terlarang: token tidak ditemukan",
+					"success": false,
+				})
+			}
+
+			for _, scope := range scopes {
+				if !claims.HasScope(scope) {
+					log.Warn().Any("payload", map[string]any{
+						"user_id":   claims.ID,
+						"client_id": claims.ClientID,
+						"scope":     scope,
+						"granted":   claims.Scope,
+					}).Msg("middleware::RequireScopes - Insufficient scope")
+
+					c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="insufficient_scope", scope="`+strings.Join(scopes, " ")+`"`)
+					return c.JSON(http.StatusForbidden, map[string]any{
+						"message": "Terlarang: token tidak memiliki scope " + scope,
+						"success": false,
+					})
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// GetSubjectFromContext builds the authz subject of the authenticated user from the JWT claims,
+// enriched with the roles and permissions granted in the database. Must run after AuthBearer.
+func GetSubjectFromContext(c echo.Context) authz.Subject {
+	sub := authz.SubjectFromClaims(GetClaimsFromContext(c))
+	if sub.ID == "" {
+		return sub
+	}
+
+	grants, err := rbac.UserGrants(c.Request().Context(), sub.ID)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", sub.ID).Msg("middleware::GetSubjectFromContext - Failed to resolve grants, using JWT role only")
+		return sub
+	}
+	for _, role := range grants.Roles {
+		if !slices.Contains(sub.Roles, role) {
+			sub.Roles = append(sub.Roles, role)
+		}
+	}
+	sub.Permissions = grants.Permissions
+	return sub
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"echo-jwt-starter/pkg/apikey"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// OAuthClientAuth authenticates the calling client with its credentials (RFC 6749 section 2.3.1), sent as
+// HTTP Basic client_id:client_secret or as client_id/client_secret form fields. When scope is not empty the
+// client must be granted it. The client is stored like APIKeyAuth does.
+func OAuthClientAuth(authenticator apikey.Authenticator, scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			clientID, clientSecret, ok := c.Request().BasicAuth()
+			if !ok {
+				clientID, clientSecret = c.FormValue("client_id"), c.FormValue("client_secret")
+			}
+			if clientID == "" || clientSecret == "" {
+				log.Warn().Str("ip", c.RealIP()).Msg("middleware::OAuthClientAuth - Missing client credentials")
+				return invalidClient(c)
+			}
+
+			client, err := authenticator.Authenticate(c.Request().Context(), clientID, clientSecret)
+			if err != nil {
+				log.Warn().Err(err).Str("client_id", clientID).Str("ip", c.RealIP()).Msg("middleware::OAuthClientAuth - Invalid client credentials")
+				return invalidClient(c)
+			}
+
+			if scope != "" && !client.HasScope(scope) {
+				log.Warn().Str("client_id", clientID).Str("scope", scope).Msg("middleware::OAuthClientAuth - Client lacks scope")
+				return c.JSON(http.StatusForbidden, map[string]any{
+					"error":             "insufficient_scope",
+					"error_description": "client is not allowed to use this endpoint",
+				})
+			}
+
+			c.Set("api_client", client)
+			return next(c)
+		}
+	}
+}
+
+func invalidClient(c echo.Context) error {
+	c.Response().Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	return c.JSON(http.StatusUnauthorized, map[string]any{
+		"error":             "invalid_client",
+		"error_description": "client authentication failed",
+	})
+}
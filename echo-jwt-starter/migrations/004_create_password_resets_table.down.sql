@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.password_resets;
\ No newline at end of file
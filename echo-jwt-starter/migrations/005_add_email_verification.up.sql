@@ -0,0 +1,20 @@
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP NULL;
+
+-- user yang sudah ada dianggap terverifikasi
+UPDATE public.users SET email_verified_at = now() WHERE email_verified_at IS NULL;
+
+-- token sekali pakai per user, dibedakan dengan purpose (email_verification, ...)
+CREATE TABLE IF NOT EXISTS public.user_tokens (
+    id UUID DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    purpose TEXT NOT NULL,
+    token_hash TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT user_tokens_pkey PRIMARY KEY (id),
+    CONSTRAINT user_tokens_token_hash_key UNIQUE (token_hash),
+    CONSTRAINT user_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_tokens_user_id_purpose_idx ON public.user_tokens (user_id, purpose, created_at);
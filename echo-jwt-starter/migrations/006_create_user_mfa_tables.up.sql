@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS public.user_mfa (
+    user_id UUID NOT NULL,
+    secret TEXT NOT NULL,
+    enabled_at TIMESTAMP NULL,
+    last_used_step BIGINT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT user_mfa_pkey PRIMARY KEY (user_id),
+    CONSTRAINT user_mfa_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.mfa_recovery_codes (
+    id UUID DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT mfa_recovery_codes_pkey PRIMARY KEY (id),
+    CONSTRAINT mfa_recovery_codes_user_id_code_hash_key UNIQUE (user_id, code_hash),
+    CONSTRAINT mfa_recovery_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS failed_login_count INT NOT NULL DEFAULT 0;
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP NULL;
@@ -0,0 +1,66 @@
+CREATE TABLE IF NOT EXISTS public.roles (
+    id UUID DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    description TEXT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT roles_pkey PRIMARY KEY (id),
+    CONSTRAINT roles_name_key UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS public.permissions (
+    id UUID DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    description TEXT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT permissions_pkey PRIMARY KEY (id),
+    CONSTRAINT permissions_name_key UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS public.role_permissions (
+    role_id UUID NOT NULL,
+    permission_id UUID NOT NULL,
+    CONSTRAINT role_permissions_pkey PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT role_permissions_role_id_fkey FOREIGN KEY (role_id) REFERENCES public.roles (id) ON DELETE CASCADE,
+    CONSTRAINT role_permissions_permission_id_fkey FOREIGN KEY (permission_id) REFERENCES public.permissions (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.user_roles (
+    user_id UUID NOT NULL,
+    role_id UUID NOT NULL,
+    granted_by UUID NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT user_roles_pkey PRIMARY KEY (user_id, role_id),
+    CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE,
+    CONSTRAINT user_roles_role_id_fkey FOREIGN KEY (role_id) REFERENCES public.roles (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_roles_role_id_idx ON public.user_roles (role_id);
+
+INSERT INTO public.roles (name, description) VALUES
+    ('admin', 'Full access to the admin API'),
+    ('user', 'Default role for registered users')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO public.permissions (name, description) VALUES
+    ('users:read', 'Read user accounts'),
+    ('users:write', 'Manage user accounts'),
+    ('roles:read', 'Read roles and role grants'),
+    ('roles:write', 'Manage roles and role grants'),
+    ('api_keys:read', 'Read API keys'),
+    ('api_keys:write', 'Manage API keys')
+ON CONFLICT (name) DO NOTHING;
+
+-- admin gets every permission
+INSERT INTO public.role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM public.roles r CROSS JOIN public.permissions p
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- existing users keep their current role as a grant
+INSERT INTO public.roles (name)
+SELECT DISTINCT u.role FROM public.users u
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO public.user_roles (user_id, role_id)
+SELECT u.id, r.id FROM public.users u JOIN public.roles r ON r.name = u.role
+ON CONFLICT DO NOTHING;
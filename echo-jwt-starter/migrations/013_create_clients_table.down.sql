@@ -0,0 +1,2 @@
+DELETE FROM public.permissions WHERE name IN ('clients:read', 'clients:write');
+DROP TABLE IF EXISTS public.clients;
\ No newline at end of file
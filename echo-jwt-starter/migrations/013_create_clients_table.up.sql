@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS public.clients (
+    id UUID DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL,
+    secret_hash TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    last_used_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT clients_pkey PRIMARY KEY (id)
+);
+
+INSERT INTO public.permissions (name, description) VALUES
+    ('clients:read', 'Read OAuth clients'),
+    ('clients:write', 'Manage OAuth clients')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO public.role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM public.roles r CROSS JOIN public.permissions p
+WHERE r.name = 'admin' AND p.name IN ('clients:read', 'clients:write')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+-- password reset memakai user_tokens (purpose password_reset), link yang masih berlaku tetap bisa dipakai
+INSERT INTO public.user_tokens (id, user_id, purpose, token_hash, expires_at, used_at, created_at)
+SELECT id, user_id, 'password_reset', token_hash, expires_at, used_at, created_at
+FROM public.password_resets
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS public.password_resets;
@@ -0,0 +1,39 @@
+package apikey
+
+import "testing"
+
+func TestGenerateAndMatch(t *testing.T) {
+	key, prefix, hash, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := Prefix(key)
+	if !ok || got != prefix {
+		t.Fatalf("Prefix(%q) = %q, %v, want %q", key, got, ok, prefix)
+	}
+	if !Matches(key, hash) {
+		t.Fatal("generated key should match its hash")
+	}
+	if Matches(key+"x", hash) {
+		t.Fatal("modified key should not match")
+	}
+}
+
+func TestPrefixRejectsMalformedKeys(t *testing.T) {
+	for _, key := range []string{"", "secret", "sk_short", "sk_0123456789", "sk_0123456789-abc", "pk_0123456789_abc"} {
+		if _, ok := Prefix(key); ok {
+			t.Errorf("Prefix(%q) should be rejected", key)
+		}
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	c := &Client{Scopes: []string{"users:read"}}
+	if !c.HasScope("users:read") || c.HasScope("users:write") {
+		t.Fatal("unexpected scope check result")
+	}
+	if !(&Client{Scopes: []string{"*"}}).HasScope("anything") {
+		t.Fatal("wildcard scope should grant everything")
+	}
+}
@@ -0,0 +1,113 @@
+package authcookie
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"echo-jwt-starter/config"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Enabled reports whether tokens are handed to browser clients as cookies (AUTH_COOKIE_ENABLED).
+func Enabled() bool {
+	return config.Envs.Cookie.Enabled
+}
+
+// TokenCookies returns the cookies set after a login or refresh: the HttpOnly access and refresh
+// cookies and a new CSRF cookie that JavaScript reads for the double-submit check.
+func TokenCookies(accessToken, refreshToken string) ([]*http.Cookie, error) {
+	csrfToken, err := NewCSRFToken()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.Envs
+	accessTtl := time.Duration(cfg.Guard.JwtTtlHours) * time.Hour
+	refreshTtl := time.Duration(cfg.Guard.JwtRefreshTtlDays) * 24 * time.Hour
+
+	return []*http.Cookie{
+		newCookie(cfg.Cookie.AccessName, accessToken, "/", accessTtl, true),
+		newCookie(cfg.Cookie.RefreshName, refreshToken, cfg.Cookie.RefreshPath, refreshTtl, true),
+		newCookie(cfg.Cookie.CsrfName, csrfToken, "/", refreshTtl, false),
+	}, nil
+}
+
+// ClearCookies returns expired cookies that remove the token and CSRF cookies, used at logout.
+func ClearCookies() []*http.Cookie {
+	cfg := config.Envs.Cookie
+	cookies := []*http.Cookie{
+		newCookie(cfg.AccessName, "", "/", 0, true),
+		newCookie(cfg.RefreshName, "", cfg.RefreshPath, 0, true),
+		newCookie(cfg.CsrfName, "", "/", 0, false),
+	}
+	for _, c := range cookies {
+		c.MaxAge = -1
+		c.Expires = time.Unix(0, 0)
+	}
+	return cookies
+}
+
+// NewCSRFToken returns a random token for the CSRF cookie.
+func NewCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ValidCSRF compares the CSRF cookie with the value echoed in the CSRF header in constant time.
+func ValidCSRF(cookie, header string) bool {
+	return cookie != "" && subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) == 1
+}
+
+// UnsafeMethod reports whether the HTTP method can change state and must pass the CSRF check.
+func UnsafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return false
+	}
+	return true
+}
+
+// AllowedOrigins returns the origins of AUTH_COOKIE_ALLOWED_ORIGINS.
+func AllowedOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(config.Envs.Cookie.AllowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+func newCookie(name, value, path string, ttl time.Duration, httpOnly bool) *http.Cookie {
+	cfg := config.Envs.Cookie
+	if path == "" {
+		path = "/"
+	}
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   cfg.Domain,
+		MaxAge:   int(ttl.Seconds()),
+		Expires:  time.Now().Add(ttl),
+		Secure:   cfg.Secure,
+		HttpOnly: httpOnly,
+		SameSite: sameSite(cfg.SameSite),
+	}
+}
+
+func sameSite(mode string) http.SameSite {
+	switch strings.ToLower(mode) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
@@ -0,0 +1,78 @@
+package authcookie
+
+import (
+	"echo-jwt-starter/config"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupConfig(t *testing.T) {
+	t.Helper()
+	config.Envs = &config.Config{}
+	config.Envs.Guard.JwtTtlHours = 1
+	config.Envs.Guard.JwtRefreshTtlDays = 30
+	config.Envs.Cookie.Enabled = true
+	config.Envs.Cookie.AccessName = "access_token"
+	config.Envs.Cookie.RefreshName = "refresh_token"
+	config.Envs.Cookie.RefreshPath = "/api/auth"
+	config.Envs.Cookie.CsrfName = "csrf_token"
+	config.Envs.Cookie.Secure = true
+	config.Envs.Cookie.SameSite = "strict"
+}
+
+func TestTokenCookies(t *testing.T) {
+	setupConfig(t)
+
+	cookies, err := TokenCookies("access", "refresh")
+	require.NoError(t, err)
+	require.Len(t, cookies, 3)
+
+	access, refresh, csrf := cookies[0], cookies[1], cookies[2]
+	assert.Equal(t, "access", access.Value)
+	assert.Equal(t, "/", access.Path)
+	assert.Equal(t, 3600, access.MaxAge)
+	assert.True(t, access.HttpOnly)
+
+	assert.Equal(t, "refresh", refresh.Value)
+	assert.Equal(t, "/api/auth", refresh.Path)
+	assert.Equal(t, 30*24*3600, refresh.MaxAge)
+	assert.True(t, refresh.HttpOnly)
+
+	// JavaScript must be able to read the CSRF cookie to echo it in the header
+	assert.NotEmpty(t, csrf.Value)
+	assert.False(t, csrf.HttpOnly)
+
+	for _, c := range cookies {
+		assert.True(t, c.Secure)
+		assert.Equal(t, http.SameSiteStrictMode, c.SameSite)
+	}
+}
+
+func TestClearCookies(t *testing.T) {
+	setupConfig(t)
+
+	for _, c := range ClearCookies() {
+		assert.Empty(t, c.Value)
+		assert.Equal(t, -1, c.MaxAge)
+	}
+}
+
+func TestValidCSRF(t *testing.T) {
+	token, err := NewCSRFToken()
+	require.NoError(t, err)
+
+	assert.True(t, ValidCSRF(token, token))
+	assert.False(t, ValidCSRF(token, "other"))
+	assert.False(t, ValidCSRF(token, ""))
+	assert.False(t, ValidCSRF("", ""))
+}
+
+func TestUnsafeMethod(t *testing.T) {
+	assert.False(t, UnsafeMethod(http.MethodGet))
+	assert.False(t, UnsafeMethod(http.MethodOptions))
+	assert.True(t, UnsafeMethod(http.MethodPost))
+	assert.True(t, UnsafeMethod(http.MethodDelete))
+}
@@ -0,0 +1,77 @@
+package authz
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEngineOwnershipAndTenantRules(t *testing.T) {
+	e, err := NewEngine(Document{
+		Rules: []Rule{
+			{Name: "owner", Effect: EffectAllow, Actions: []string{"users:read", "users:update"}, Resources: []string{"user"}, Conditions: []string{"subject.id == resource.owner_id"}},
+			{Name: "manager-tenant", Effect: EffectAllow, Roles: []string{"manager"}, Actions: []string{"users:read"}, Resources: []string{"user"}, Conditions: []string{"subject.tenant_id == resource.tenant_id"}},
+			{Name: "no-delete-root", Effect: EffectDeny, Actions: []string{"*:delete"}, Resources: []string{"*"}, Conditions: []string{"resource.id == 'root'"}},
+			{Name: "admin", Effect: EffectAllow, Roles: []string{"admin"}, Actions: []string{"*"}, Resources: []string{"*"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	alice := Subject{ID: "alice", Roles: []string{"user"}}
+	manager := Subject{ID: "bob", Roles: []string{"manager"}, Attrs: map[string]string{"tenant_id": "t1"}}
+	admin := Subject{ID: "carol", Roles: []string{"admin"}}
+
+	cases := []struct {
+		name   string
+		sub    Subject
+		action string
+		res    Resource
+		want   bool
+	}{
+		{"owner reads own record", alice, "users:read", Resource{Type: "user", ID: "alice", OwnerID: "alice"}, true},
+		{"owner cannot read others", alice, "users:read", Resource{Type: "user", ID: "dave", OwnerID: "dave"}, false},
+		{"owner cannot delete", alice, "users:delete", Resource{Type: "user", ID: "alice", OwnerID: "alice"}, false},
+		{"manager reads own tenant", manager, "users:read", Resource{Type: "user", ID: "dave", Attrs: map[string]string{"tenant_id": "t1"}}, true},
+		{"manager other tenant", manager, "users:read", Resource{Type: "user", ID: "erin", Attrs: map[string]string{"tenant_id": "t2"}}, false},
+		{"missing attribute never matches", Subject{ID: "x", Roles: []string{"manager"}}, "users:read", Resource{Type: "user", ID: "y"}, false},
+		{"admin allowed", admin, "users:delete", Resource{Type: "user", ID: "dave"}, true},
+		{"deny wins over admin", admin, "users:delete", Resource{Type: "user", ID: "root"}, false},
+	}
+
+	for _, tc := range cases {
+		if got := e.Evaluate(ctx, tc.sub, tc.action, tc.res); got.Allowed != tc.want {
+			t.Errorf("%s: allowed = %v (rule %q, %s), want %v", tc.name, got.Allowed, got.Rule, got.Reason, tc.want)
+		}
+	}
+}
+
+func TestNewEngineRejectsInvalidRules(t *testing.T) {
+	docs := []Document{
+		{Default: "maybe"},
+		{Rules: []Rule{{Name: "r", Effect: "permit", Actions: []string{"*"}, Resources: []string{"*"}}}},
+		{Rules: []Rule{{Name: "r", Effect: EffectAllow, Resources: []string{"*"}}}},
+		{Rules: []Rule{{Name: "r", Effect: EffectAllow, Actions: []string{"*"}, Resources: []string{"*"}, Conditions: []string{"subject.id"}}}},
+		{Rules: []Rule{{Name: "r", Effect: EffectAllow, Actions: []string{"*"}, Resources: []string{"*"}, Conditions: []string{"id == resource.id"}}}},
+	}
+	for i, doc := range docs {
+		if _, err := NewEngine(doc); err == nil {
+			t.Errorf("document %d: expected error", i)
+		}
+	}
+}
+
+func TestAuthorizeReturnsForbidden(t *testing.T) {
+	defer SetPolicy(DefaultPolicy())
+	SetPolicy(DefaultPolicy())
+
+	ctx := context.Background()
+	res := Resource{Type: "user", ID: "u2", OwnerID: "u2"}
+	if err := Authorize(ctx, Subject{ID: "u2", Roles: []string{"user"}}, "users:update", res); err != nil {
+		t.Fatalf("owner should be allowed, got %v", err)
+	}
+	if err := Authorize(ctx, Subject{ID: "u1", Roles: []string{"user"}}, "users:update", res); err == nil {
+		t.Fatal("expected forbidden error")
+	}
+}
@@ -0,0 +1,57 @@
+package clientip
+
+import (
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ParseTrustedProxies parses APP_TRUSTED_PROXIES, a comma separated list of CIDRs or single IPs of the
+// reverse proxies in front of the server.
+func ParseTrustedProxies(raw string) ([]*net.IPNet, error) {
+	var ranges []*net.IPNet
+	for _, entry := range strings.Split(raw, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, &net.ParseError{Type: "IP address", Text: entry}
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			ranges = append(ranges, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipRange, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, ipRange)
+	}
+	return ranges, nil
+}
+
+// Extractor returns the peer address as client IP, or the X-Forwarded-For entry appended by the
+// first untrusted hop when trusted proxies are configured. Client supplied forwarded headers are
+// never trusted otherwise.
+func Extractor(trustedProxies string) (echo.IPExtractor, error) {
+	ranges, err := ParseTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranges) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// only the configured ranges, not echo's default of every private network
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, ipRange := range ranges {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
@@ -0,0 +1,58 @@
+package clientip
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractorIgnoresForwardedHeadersByDefault(t *testing.T) {
+	extract, err := Extractor("")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/api/auth/login", nil)
+	req.RemoteAddr = "203.0.113.7:51000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	req.Header.Set("X-Real-IP", "198.51.100.2")
+
+	assert.Equal(t, "203.0.113.7", extract(req))
+}
+
+func TestExtractorTrustsConfiguredProxies(t *testing.T) {
+	extract, err := Extractor("10.0.0.0/8, 192.0.2.10")
+	require.NoError(t, err)
+
+	// the client spoofs an entry, the proxy appends the real client address
+	req := httptest.NewRequest("POST", "/api/auth/login", nil)
+	req.RemoteAddr = "10.1.2.3:51000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7, 192.0.2.10")
+	assert.Equal(t, "203.0.113.7", extract(req))
+
+	// forwarded headers from an untrusted peer are ignored
+	req = httptest.NewRequest("POST", "/api/auth/login", nil)
+	req.RemoteAddr = "203.0.113.9:51000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	assert.Equal(t, "203.0.113.9", extract(req))
+
+	// private networks are not trusted unless configured
+	req = httptest.NewRequest("POST", "/api/auth/login", nil)
+	req.RemoteAddr = "192.168.1.5:51000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	assert.Equal(t, "192.168.1.5", extract(req))
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	ranges, err := ParseTrustedProxies(" 10.0.0.0/8 ,192.0.2.10,2001:db8::1,")
+	require.NoError(t, err)
+	require.Len(t, ranges, 3)
+	assert.Equal(t, "10.0.0.0/8", ranges[0].String())
+	assert.Equal(t, "192.0.2.10/32", ranges[1].String())
+	assert.Equal(t, "2001:db8::1/128", ranges[2].String())
+
+	_, err = ParseTrustedProxies("10.0.0.0/33")
+	assert.Error(t, err)
+	_, err = ParseTrustedProxies("proxy.local")
+	assert.Error(t, err)
+}
@@ -2,9 +2,11 @@ package jwthandler
 
 import (
 	"echo-jwt-starter/config"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
@@ -13,39 +15,90 @@ type TokenType string
 const (
 	AccessToken  TokenType = "access_token"
 	RefreshToken TokenType = "refresh_token"
+	// MfaPendingToken is issued after a valid password for MFA enabled accounts and can only be
+	// exchanged for real tokens at the second login step.
+	MfaPendingToken TokenType = "mfa_pending"
+	// ClientToken is issued to machine clients by the client_credentials grant, it carries no user.
+	ClientToken TokenType = "client_token"
 )
 
 type CustomClaims struct {
-	ID   string `json:"id"`
-	Role string `json:"role"`
+	ID       string `json:"id"`
+	Role     string `json:"role"`
+	FamilyID string `json:"fid,omitempty"`       // refresh token family (login session) the token belongs to
+	MFA      bool   `json:"mfa,omitempty"`       // the login was completed with a second factor
+	ClientID string `json:"client_id,omitempty"` // set on client tokens instead of ID
+	Scope    string `json:"scope,omitempty"`     // space separated scopes granted to the token
 	jwt.RegisteredClaims
 }
 
+// JTI returns the unique token identifier (jti claim).
+func (c *CustomClaims) JTI() string {
+	return c.RegisteredClaims.ID
+}
+
+// ExpiresAtTime returns the expiration time of the token, or zero time when not set.
+func (c *CustomClaims) ExpiresAtTime() time.Time {
+	if c.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return c.ExpiresAt.Time
+}
+
 type Payload struct {
 	ID              string
 	Role            string
+	TokenID         string // optional, a random jti is generated when empty
+	FamilyID        string
+	MFA             bool
+	ClientID        string
+	Scope           string
 	Subject         TokenType
 	ExpirationHours int
+	ExpiresIn       time.Duration // optional, overrides ExpirationHours for short-lived tokens
 }
 
 // GenerateToken generates a new JWT token
 func GenerateToken(p Payload) (string, error) {
 	now := time.Now().UTC()
 
+	tokenID := p.TokenID
+	if tokenID == "" {
+		tokenID = uuid.NewString()
+	}
+
+	expiresIn := p.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = time.Duration(p.ExpirationHours) * time.Hour
+	}
+
 	claims := CustomClaims{
-		ID:   p.ID,
-		Role: p.Role,
+		ID:       p.ID,
+		Role:     p.Role,
+		FamilyID: p.FamilyID,
+		MFA:      p.MFA,
+		ClientID: p.ClientID,
+		Scope:    p.Scope,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			Issuer:    config.Envs.App.Name,
 			Subject:   string(p.Subject),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(p.ExpirationHours) * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiresIn)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	signedToken, err := token.SignedString([]byte(config.Envs.Guard.JwtSecret))
+	ring, err := getKeyRing()
+	if err != nil {
+		log.Error().Err(err).Msg("jwthandler::GenerateToken - signing key unavailable")
+		return "", err
+	}
+	key := ring.Current()
+
+	token := jwt.NewWithClaims(key.Method, &claims)
+	token.Header["kid"] = key.ID
+	signedToken, err := token.SignedString(key.signingKey)
 	if err != nil {
 		log.Error().Err(err).Msg("jwthandler::GenerateToken - signing failed")
 		return "", err
@@ -58,9 +111,25 @@ func GenerateToken(p Payload) (string, error) {
 func ParseToken(tokenString string) (*CustomClaims, error) {
 	claims := &CustomClaims{}
 
+	ring, err := getKeyRing()
+	if err != nil {
+		log.Error().Err(err).Msg("jwthandler::ParseToken - verification key unavailable")
+		return nil, err
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.Envs.Guard.JwtSecret), nil
-	})
+		// tokens issued before kid was introduced are verified with the current key
+		key := ring.Current()
+		if kid, ok := token.Header["kid"].(string); ok {
+			if key, ok = ring.Lookup(kid); !ok {
+				return nil, fmt.Errorf("unknown kid %q", kid)
+			}
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %q for kid %q", token.Method.Alg(), key.ID)
+		}
+		return key.verifyKey, nil
+	}, jwt.WithValidMethods(ring.algorithms()))
 	if err != nil {
 		log.Error().Err(err).Msg("jwthandler::ParseToken - parse failed")
 		return nil, err
@@ -0,0 +1,146 @@
+package jwthandler
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"echo-jwt-starter/config"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePrivateKey(t *testing.T, privateKey any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "private.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func useKey(t *testing.T, key *Key, previous ...*Key) {
+	t.Helper()
+	ring, err := NewKeyRing(key, previous...)
+	require.NoError(t, err)
+	setKeyRing(ring)
+}
+
+func setupConfig(t *testing.T) {
+	t.Helper()
+	config.Envs = &config.Config{}
+	config.Envs.App.Name = "test"
+	t.Cleanup(func() { setKeyRing(nil) })
+}
+
+func TestGenerateAndParseToken(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		alg     string
+		secret  string
+		private any
+		kty     string
+	}{
+		{alg: "HS256", secret: "secret"},
+		{alg: "RS256", private: rsaKey, kty: "RSA"},
+		{alg: "ES256", private: ecKey, kty: "EC"},
+		{alg: "EdDSA", private: edKey, kty: "OKP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			setupConfig(t)
+			keyFile := ""
+			if tt.private != nil {
+				keyFile = writePrivateKey(t, tt.private)
+			}
+
+			key, err := LoadKey(tt.alg, "", tt.secret, keyFile)
+			require.NoError(t, err)
+			useKey(t, key)
+
+			token, err := GenerateToken(Payload{ID: "user-1", Role: "admin", Subject: AccessToken, ExpirationHours: 1})
+			require.NoError(t, err)
+
+			claims, err := ParseToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", claims.ID)
+			assert.Equal(t, "admin", claims.Role)
+			assert.NotEmpty(t, claims.JTI())
+
+			jwks := JWKS()
+			if key.IsSymmetric() {
+				assert.Empty(t, jwks.Keys)
+				return
+			}
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, tt.kty, jwks.Keys[0].Kty)
+			assert.Equal(t, tt.alg, jwks.Keys[0].Alg)
+			assert.Equal(t, key.ID, jwks.Keys[0].Kid)
+		})
+	}
+}
+
+func TestParseTokenRejectsOtherKey(t *testing.T) {
+	setupConfig(t)
+
+	key, err := LoadKey("HS256", "", "secret-a", "")
+	require.NoError(t, err)
+	useKey(t, key)
+	token, err := GenerateToken(Payload{ID: "user-1", Subject: AccessToken, ExpirationHours: 1})
+	require.NoError(t, err)
+
+	other, err := LoadKey("HS256", "other", "secret-b", "")
+	require.NoError(t, err)
+	useKey(t, other)
+	_, err = ParseToken(token)
+	assert.Error(t, err)
+}
+
+func TestParseTokenWithPreviousKey(t *testing.T) {
+	setupConfig(t)
+
+	oldKey, err := LoadKey("HS256", "v1", "secret-old", "")
+	require.NoError(t, err)
+	useKey(t, oldKey)
+	token, err := GenerateToken(Payload{ID: "user-1", Subject: AccessToken, ExpirationHours: 1})
+	require.NoError(t, err)
+
+	newKey, err := LoadKey("HS256", "v2", "secret-new", "")
+	require.NoError(t, err)
+	useKey(t, newKey, oldKey)
+
+	claims, err := ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.ID)
+
+	// once the old key is retired the token is rejected
+	useKey(t, newKey)
+	_, err = ParseToken(token)
+	assert.Error(t, err)
+}
+
+func TestParsePreviousKeys(t *testing.T) {
+	keys, err := parsePreviousKeys("v1:HS256:secret-a, v0:HS256:secret-b")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "v1", keys[0].ID)
+	assert.Equal(t, "v0", keys[1].ID)
+
+	_, err = parsePreviousKeys("invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package jwthandler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyDirRotation(t *testing.T) {
+	setupConfig(t)
+	dir := t.TempDir()
+	now := time.Now()
+
+	first, err := GenerateKeyFile(dir, "ES256", now)
+	require.NoError(t, err)
+
+	ring, err := LoadKeyDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, first.Kid, ring.Current().ID)
+	setKeyRing(ring)
+
+	token, err := GenerateToken(Payload{ID: "user-1", Subject: AccessToken, ExpirationHours: 1})
+	require.NoError(t, err)
+
+	// a generated key is not used for signing until it is promoted
+	second, err := GenerateKeyFile(dir, "ES256", now)
+	require.NoError(t, err)
+	require.NoError(t, PromoteKey(dir, second.Kid, now))
+
+	ring, err = LoadKeyDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, second.Kid, ring.Current().ID)
+	setKeyRing(ring)
+	assert.Len(t, JWKS().Keys, 2)
+
+	_, err = ParseToken(token)
+	assert.NoError(t, err)
+
+	// the demoted key stays until the grace period has passed
+	retired, err := RetireKeys(dir, time.Hour, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, retired)
+
+	retired, err = RetireKeys(dir, time.Hour, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.Kid}, retired)
+
+	ring, err = LoadKeyDir(dir)
+	require.NoError(t, err)
+	setKeyRing(ring)
+	_, err = ParseToken(token)
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package jwthandler
+
+import (
+	"slices"
+	"strings"
+)
+
+// AllScopes is the wildcard scope, a token carrying it is not limited by RequireScopes.
+const AllScopes = "*"
+
+// ParseScope splits a space separated scope string, dropping duplicates while keeping the order.
+func ParseScope(scope string) []string {
+	fields := strings.Fields(scope)
+	scopes := make([]string, 0, len(fields))
+	for _, s := range fields {
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// Scopes returns the scopes granted to the token.
+func (c *CustomClaims) Scopes() []string {
+	return ParseScope(c.Scope)
+}
+
+// Scoped reports whether the token is limited to its scope claim. User tokens issued without
+// a scope are unrestricted, client tokens only ever get the scopes they carry.
+func (c *CustomClaims) Scoped() bool {
+	return c.Scope != "" || c.Subject == string(ClientToken)
+}
+
+// HasScope reports whether the token grants the scope, "*" grants every scope.
+func (c *CustomClaims) HasScope(scope string) bool {
+	if !c.Scoped() {
+		return true
+	}
+	scopes := c.Scopes()
+	return slices.Contains(scopes, AllScopes) || slices.Contains(scopes, scope)
+}
@@ -0,0 +1,40 @@
+package jwthandler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScope(t *testing.T) {
+	assert.Equal(t, []string{"profile", "sessions"}, ParseScope("  profile sessions profile "))
+	assert.Empty(t, ParseScope(""))
+}
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims CustomClaims
+		scope  string
+		want   bool
+	}{
+		{name: "unscoped user token", claims: CustomClaims{ID: "u1"}, scope: "admin", want: true},
+		{name: "granted scope", claims: CustomClaims{ID: "u1", Scope: "profile sessions"}, scope: "sessions", want: true},
+		{name: "missing scope", claims: CustomClaims{ID: "u1", Scope: "profile"}, scope: "admin", want: false},
+		{name: "wildcard", claims: CustomClaims{ID: "u1", Scope: "*"}, scope: "admin", want: true},
+		{name: "client token without scope", claims: clientClaims(""), scope: "reports:read", want: false},
+		{name: "client token with scope", claims: clientClaims("reports:read"), scope: "reports:read", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.claims.HasScope(tt.scope))
+		})
+	}
+}
+
+func clientClaims(scope string) CustomClaims {
+	c := CustomClaims{ClientID: "c1", Scope: scope}
+	c.Subject = string(ClientToken)
+	return c
+}
@@ -0,0 +1,111 @@
+package loginguard
+
+import (
+	"sync"
+	"time"
+)
+
+// Backoff returns how long a key stays locked after the given number of consecutive failures.
+// The first threshold-1 failures are free, then the lock doubles from base on every failure up to max.
+func Backoff(failures, threshold int, base, max time.Duration) time.Duration {
+	if threshold <= 0 || failures < threshold {
+		return 0
+	}
+
+	lock := base
+	for i := threshold; i < failures; i++ {
+		lock *= 2
+		if lock >= max {
+			return max
+		}
+	}
+	if lock > max {
+		return max
+	}
+	return lock
+}
+
+// Guard tracks consecutive failed login attempts per key (e.g. client IP or email) in memory.
+// It is not shared between instances, account lockout itself is persisted on the users table.
+type Guard struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	threshold int
+	base      time.Duration
+	max       time.Duration
+}
+
+type entry struct {
+	failures    int
+	lockedUntil time.Time
+	lastFailure time.Time
+}
+
+// New creates a Guard and starts a janitor forgetting keys idle for longer than max every cleanupInterval.
+func New(threshold int, base, max, cleanupInterval time.Duration) *Guard {
+	g := &Guard{
+		entries:   make(map[string]*entry),
+		threshold: threshold,
+		base:      base,
+		max:       max,
+	}
+
+	if cleanupInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cleanupInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				g.evictIdle(time.Now())
+			}
+		}()
+	}
+
+	return g
+}
+
+// LockedFor returns the remaining lock duration of the key, zero when it is not locked.
+func (g *Guard) LockedFor(key string, now time.Time) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	e, found := g.entries[key]
+	if !found || !now.Before(e.lockedUntil) {
+		return 0
+	}
+	return e.lockedUntil.Sub(now)
+}
+
+// Fail records a failed attempt and returns the resulting lock duration.
+func (g *Guard) Fail(key string, now time.Time) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	e, found := g.entries[key]
+	if !found {
+		e = &entry{}
+		g.entries[key] = e
+	}
+
+	e.failures++
+	e.lastFailure = now
+	lock := Backoff(e.failures, g.threshold, g.base, g.max)
+	if lock > 0 {
+		e.lockedUntil = now.Add(lock)
+	}
+	return lock
+}
+
+// Reset forgets the failures of the key, e.g. after a successful login or an admin unlock.
+func (g *Guard) Reset(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.entries, key)
+}
+
+func (g *Guard) evictIdle(now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key, e := range g.entries {
+		if now.Sub(e.lastFailure) > g.max && !now.Before(e.lockedUntil) {
+			delete(g.entries, key)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// FileNotifier writes every message as an .eml file into a directory (mail sink for development).
+type FileNotifier struct {
+	dir string
+}
+
+func NewFileNotifier(dir string) *FileNotifier {
+	return &FileNotifier{dir: dir}
+}
+
+func (n *FileNotifier) Send(_ context.Context, msg Message) error {
+	if err := os.MkdirAll(n.dir, 0o755); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	filename := filepath.Join(n.dir, fmt.Sprintf("%s_%s.eml", now.Format("20060102T150405.000000000"), recipient))
+
+	content := fmt.Sprintf("Date: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n",
+		now.Format(time.RFC1123Z), msg.To, msg.Subject, msg.Body)
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		return err
+	}
+
+	log.Debug().Str("to", msg.To).Str("file", filename).Msg("notifier::FileNotifier - message written")
+	return nil
+}
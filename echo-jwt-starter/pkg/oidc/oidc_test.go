@@ -0,0 +1,191 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockProvider is a minimal OpenID Connect provider issuing RS256 ID tokens for a single code.
+type mockProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu        sync.Mutex
+	challenge string
+	nonce     string
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &mockProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Discovery{
+			Issuer:                m.server.URL,
+			AuthorizationEndpoint: m.server.URL + "/authorize",
+			TokenEndpoint:         m.server.URL + "/token",
+			JwksURI:               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jwkSet{Keys: []jwk{{
+			Kty: "RSA",
+			Use: "sig",
+			Kid: "mock-key",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		m.mu.Lock()
+		challenge, nonce := m.challenge, m.nonce
+		m.mu.Unlock()
+
+		if r.Form.Get("code") != "valid-code" || S256Challenge(r.Form.Get("code_verifier")) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(TokenResponse{
+			AccessToken: "provider-access-token",
+			TokenType:   "Bearer",
+			IDToken:     m.sign(t, "client-1", nonce, time.Now().Add(time.Hour)),
+		})
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockProvider) authorize(t *testing.T, authURL string) {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.challenge = u.Query().Get("code_challenge")
+	m.nonce = u.Query().Get("nonce")
+}
+
+func (m *mockProvider) sign(t *testing.T, audience, nonce string, expiresAt time.Time) string {
+	t.Helper()
+	claims := IDTokenClaims{
+		Email:         "jane@example.com",
+		EmailVerified: true,
+		Nonce:         nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.server.URL,
+			Subject:   "external-123",
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "mock-key"
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func (m *mockProvider) provider() *Provider {
+	return NewProvider(ProviderConfig{
+		Name:        "mock",
+		Issuer:      m.server.URL,
+		ClientID:    "client-1",
+		RedirectURL: "http://localhost:3000/oidc/callback",
+	}, m.server.Client())
+}
+
+func TestAuthorizationCodeFlowWithPKCE(t *testing.T) {
+	m := newMockProvider(t)
+	p := m.provider()
+	ctx := context.Background()
+
+	verifier, _ := GenerateVerifier()
+	authURL, err := p.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	q, _ := url.ParseQuery(mustParseURL(t, authURL).RawQuery)
+	if q.Get("code_challenge_method") != "S256" || q.Get("state") != "state-1" || q.Get("client_id") != "client-1" {
+		t.Fatalf("unexpected authorization request: %s", authURL)
+	}
+	m.authorize(t, authURL)
+
+	if _, err = p.Exchange(ctx, "valid-code", "wrong-verifier"); err == nil {
+		t.Fatal("expected exchange with a wrong code verifier to fail")
+	}
+
+	token, err := p.Exchange(ctx, "valid-code", verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+
+	claims, err := p.VerifyIDToken(ctx, token.IDToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+	if claims.Subject != "external-123" || claims.Email != "jane@example.com" || !bool(claims.EmailVerified) {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	if _, err = p.VerifyIDToken(ctx, token.IDToken, "other-nonce"); !errors.Is(err, ErrNonceMismatch) {
+		t.Fatalf("expected nonce mismatch, got %v", err)
+	}
+}
+
+func TestVerifyIDTokenRejectsInvalidTokens(t *testing.T) {
+	m := newMockProvider(t)
+	p := m.provider()
+	ctx := context.Background()
+
+	cases := map[string]string{
+		"wrong audience": m.sign(t, "other-client", "n", time.Now().Add(time.Hour)),
+		"expired":        m.sign(t, "client-1", "n", time.Now().Add(-time.Hour)),
+	}
+	for name, raw := range cases {
+		if _, err := p.VerifyIDToken(ctx, raw, "n"); !errors.Is(err, ErrInvalidIDToken) {
+			t.Errorf("%s: expected ErrInvalidIDToken, got %v", name, err)
+		}
+	}
+
+	// token signed by a key the provider never published
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	m.key = other
+	if _, err := p.VerifyIDToken(ctx, m.sign(t, "client-1", "n", time.Now().Add(time.Hour)), "n"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Errorf("foreign key: expected ErrInvalidIDToken, got %v", err)
+	}
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
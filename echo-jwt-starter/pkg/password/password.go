@@ -0,0 +1,117 @@
+package password
+
+import (
+	"echo-jwt-starter/config"
+	"errors"
+	"fmt"
+)
+
+const (
+	Argon2id = "argon2id"
+	Bcrypt   = "bcrypt"
+)
+
+var (
+	// ErrMismatch is returned by Verify when the password does not match the hash.
+	ErrMismatch = errors.New("password: hash and password do not match")
+	// ErrUnknownFormat is returned when no hasher recognizes the encoded hash.
+	ErrUnknownFormat = errors.New("password: unknown hash format")
+)
+
+// Hasher hashes passwords into self-describing encoded strings (PHC format for argon2id,
+// modular crypt format for bcrypt) that carry the algorithm and its parameters.
+type Hasher interface {
+	// Hash returns the encoded hash of password.
+	Hash(password string) (string, error)
+	// Verify compares password with an encoded hash of this algorithm, it returns ErrMismatch on mismatch.
+	Verify(encoded, password string) error
+	// Identifies reports whether the encoded hash was produced by this algorithm.
+	Identifies(encoded string) bool
+	// NeedsRehash reports whether the encoded hash was created with weaker settings than the hasher's.
+	NeedsRehash(encoded string) bool
+}
+
+// Params selects the preferred algorithm and its cost settings.
+type Params struct {
+	Algorithm         string
+	Argon2Memory      uint32 // KiB
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	BcryptCost        int
+}
+
+// DefaultParams follows the RFC 9106 recommendation for memory constrained environments.
+func DefaultParams() Params {
+	return Params{
+		Algorithm:         Argon2id,
+		Argon2Memory:      64 * 1024,
+		Argon2Iterations:  3,
+		Argon2Parallelism: 2,
+		BcryptCost:        12,
+	}
+}
+
+// Manager hashes new passwords with the preferred algorithm and verifies hashes of every supported
+// algorithm, so stored hashes can be upgraded transparently.
+type Manager struct {
+	preferred Hasher
+	hashers   []Hasher
+}
+
+// NewManager creates a Manager preferring p.Algorithm.
+func NewManager(p Params) (*Manager, error) {
+	argon, err := NewArgon2id(p.Argon2Memory, p.Argon2Iterations, p.Argon2Parallelism)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := NewBcrypt(p.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{hashers: []Hasher{argon, bc}}
+	switch p.Algorithm {
+	case Argon2id:
+		m.preferred = argon
+	case Bcrypt:
+		m.preferred = bc
+	default:
+		return nil, fmt.Errorf("password: unknown algorithm %q", p.Algorithm)
+	}
+	return m, nil
+}
+
+// NewFromConfig creates a Manager from the PASSWORD_* settings.
+func NewFromConfig() (*Manager, error) {
+	cfg := config.Envs.Password
+	return NewManager(Params{
+		Algorithm:         cfg.HashAlgorithm,
+		Argon2Memory:      uint32(cfg.Argon2MemoryKiB),
+		Argon2Iterations:  uint32(cfg.Argon2Iterations),
+		Argon2Parallelism: uint8(cfg.Argon2Parallelism),
+		BcryptCost:        cfg.BcryptCost,
+	})
+}
+
+// Hash hashes password with the preferred algorithm.
+func (m *Manager) Hash(password string) (string, error) {
+	return m.preferred.Hash(password)
+}
+
+// Verify checks password against a hash of any supported algorithm.
+func (m *Manager) Verify(encoded, password string) error {
+	for _, h := range m.hashers {
+		if h.Identifies(encoded) {
+			return h.Verify(encoded, password)
+		}
+	}
+	return ErrUnknownFormat
+}
+
+// NeedsRehash reports whether the hash should be replaced by a hash of the preferred algorithm and settings.
+func (m *Manager) NeedsRehash(encoded string) bool {
+	if !m.preferred.Identifies(encoded) {
+		return true
+	}
+	return m.preferred.NeedsRehash(encoded)
+}
@@ -0,0 +1,80 @@
+package rbac
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxCacheEntries bounds the cache, expired entries are swept once it is reached.
+const maxCacheEntries = 10000
+
+type cacheEntry struct {
+	grants    Grants
+	expiresAt time.Time
+}
+
+// Cache keeps resolved grants for a short time so permission checks don't hit the database on
+// every request. Changes made through the admin API invalidate the cache right away, other
+// changes become visible after ttl.
+type Cache struct {
+	resolver Resolver
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// NewCache wraps resolver with a cache, a zero ttl disables caching.
+func NewCache(resolver Resolver, ttl time.Duration) *Cache {
+	return &Cache{
+		resolver: resolver,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]cacheEntry),
+	}
+}
+
+// UserGrants returns the cached grants of the user, loading them from the wrapped resolver when missing or expired.
+func (c *Cache) UserGrants(ctx context.Context, userId string) (Grants, error) {
+	if c.ttl <= 0 {
+		return c.resolver.UserGrants(ctx, userId)
+	}
+
+	now := c.now()
+	c.mu.Lock()
+	entry, ok := c.entries[userId]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.grants, nil
+	}
+
+	grants, err := c.resolver.UserGrants(ctx, userId)
+	if err != nil {
+		return Grants{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCacheEntries {
+		for id, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+	}
+	c.entries[userId] = cacheEntry{grants: grants, expiresAt: now.Add(c.ttl)}
+	return grants, nil
+}
+
+// Invalidate drops the cached grants of the user, or of every user when userId is empty.
+func (c *Cache) Invalidate(userId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if userId == "" {
+		c.entries = make(map[string]cacheEntry)
+		return
+	}
+	delete(c.entries, userId)
+}
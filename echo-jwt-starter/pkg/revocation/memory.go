@@ -0,0 +1,57 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a process local Store, entries are evicted once the token expires.
+// It is not shared between instances, use the Postgres store when running more than one replica.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries map[string]time.Time
+}
+
+// NewMemoryStore creates a MemoryStore and starts a janitor evicting expired entries every cleanupInterval.
+func NewMemoryStore(cleanupInterval time.Duration) *MemoryStore {
+	s := &MemoryStore{
+		entries: make(map[string]time.Time),
+	}
+
+	if cleanupInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cleanupInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				s.evictExpired(time.Now())
+			}
+		}()
+	}
+
+	return s
+}
+
+func (s *MemoryStore) Revoke(_ context.Context, jti string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[jti] = expiresAt
+	return nil
+}
+
+func (s *MemoryStore) IsRevoked(_ context.Context, jti string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	expiresAt, found := s.entries[jti]
+	return found && time.Now().Before(expiresAt), nil
+}
+
+func (s *MemoryStore) evictExpired(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for jti, expiresAt := range s.entries {
+		if !now.Before(expiresAt) {
+			delete(s.entries, jti)
+		}
+	}
+}
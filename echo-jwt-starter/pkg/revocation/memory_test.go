@@ -0,0 +1,39 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreRevoke(t *testing.T) {
+	s := NewMemoryStore(0)
+	ctx := context.Background()
+
+	revoked, err := s.IsRevoked(ctx, "jti-1")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	assert.NoError(t, s.Revoke(ctx, "jti-1", time.Now().Add(time.Hour)))
+
+	revoked, err = s.IsRevoked(ctx, "jti-1")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestMemoryStoreEvictExpired(t *testing.T) {
+	s := NewMemoryStore(0)
+	ctx := context.Background()
+
+	assert.NoError(t, s.Revoke(ctx, "expired", time.Now().Add(-time.Second)))
+	assert.NoError(t, s.Revoke(ctx, "active", time.Now().Add(time.Hour)))
+
+	revoked, _ := s.IsRevoked(ctx, "expired")
+	assert.False(t, revoked)
+
+	s.evictExpired(time.Now())
+	assert.Len(t, s.entries, 1)
+	assert.Contains(t, s.entries, "active")
+}
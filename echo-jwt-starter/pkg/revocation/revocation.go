@@ -0,0 +1,57 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store keeps the jti of revoked tokens until the token itself would have expired.
+type Store interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+var (
+	store Store
+	mu    sync.RWMutex
+)
+
+// SetStore replaces the store used by Revoke and IsRevoked.
+func SetStore(s Store) {
+	mu.Lock()
+	defer mu.Unlock()
+	store = s
+}
+
+// GetStore returns the active store, falling back to an in-memory store.
+func GetStore() Store {
+	mu.RLock()
+	s := store
+	mu.RUnlock()
+	if s != nil {
+		return s
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if store == nil {
+		store = NewMemoryStore(time.Minute)
+	}
+	return store
+}
+
+// Revoke marks the token as revoked in the active store.
+func Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	return GetStore().Revoke(ctx, jti, expiresAt)
+}
+
+// IsRevoked reports whether the token was revoked in the active store.
+func IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return GetStore().IsRevoked(ctx, jti)
+}
+
+// FamilyKey is the store key that revokes every access token of a refresh token family (login session).
+func FamilyKey(familyId string) string {
+	return "fid:" + familyId
+}
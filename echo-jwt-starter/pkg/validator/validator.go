@@ -3,6 +3,7 @@ package validator
 // src: /pkg/validator/validator.go
 
 import (
+	"echo-jwt-starter/pkg/password"
 	"reflect"
 	"strings"
 
@@ -128,28 +129,9 @@ func isEmailBlacklistV2(fl validator.FieldLevel) bool {
 	return true
 }
 
+// isStrongPassword applies the context free rules of the configured password policy (PASSWORD_*).
 func isStrongPassword(fl validator.FieldLevel) bool {
-	password := fl.Field().String()
-	if len(password) < 12 {
-		return false
-	}
-
-	hasUppercase := false
-	hasLowercase := false
-	hasNumber := false
-
-	for _, char := range password {
-		switch {
-		case char >= 'A' && char <= 'Z':
-			hasUppercase = true
-		case char >= 'a' && char <= 'z':
-			hasLowercase = true
-		case char >= '0' && char <= '9':
-			hasNumber = true
-		}
-	}
-
-	return hasUppercase && hasLowercase && hasNumber
+	return len(password.PolicyFromConfig().Check(fl.Field().String())) == 0
 }
 
 func isUniqueInSlice(fl validator.FieldLevel) bool {
@@ -28,6 +28,9 @@ type Config struct {
 	APIKeys struct {
 		XApiKey string `env:"X_API_KEY" required:"true"`
 	}
+	Pagination struct {
+		CursorSecret string `env:"PAGINATION_CURSOR_SECRET" env-description:"secret signing cursor tokens, a random per-process secret when empty"`
+	}
 	DB struct {
 		Postgres struct {
 			Host              string `env:"DB_HOST" env-default:"localhost" required:"true"`
@@ -1,8 +1,24 @@
 package entity
 
+import "time"
+
 type UserDB struct {
-	Id       string `json:"id"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Role     string `json:"role"`
+	Id        string    `json:"id"`
+	Email     string    `json:"email"`
+	Password  string    `json:"password"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserFilter narrows and orders the user listing. CreatedFrom (inclusive) and CreatedBefore
+// (exclusive) are UTC datetimes (utils.DateTimeFormat), Sort is the raw sort parameter checked by
+// the repository and only applies to offset pages.
+type UserFilter struct {
+	Role          string
+	Email         string
+	CreatedFrom   string
+	CreatedBefore string
+	Sort          string
+	Limit         int
+	Offset        int
 }
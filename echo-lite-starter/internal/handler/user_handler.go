@@ -4,11 +4,15 @@ import (
 	"echo-lite-starter/internal/dto"
 	"echo-lite-starter/internal/service"
 	"echo-lite-starter/pkg/errmsg"
+	"echo-lite-starter/pkg/mergepatch"
 	"echo-lite-starter/pkg/response"
 	"echo-lite-starter/pkg/utils"
+	"encoding/json"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
+	"io"
 	"net/http"
+	"strings"
 )
 
 type UserHandler struct {
@@ -44,13 +48,38 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 }
 
 func (h *UserHandler) GetUsers(c echo.Context) error {
-	results, err := h.Service.Get(c.Request().Context())
+	var req dto.UserListRequest
+	if err := c.Bind(&req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetUsers - Failed to bind query params")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Warn().Err(err).Msg("handler::GetUsers - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	// Keyset pages for large tables, offset pages (with total) otherwise
+	if req.Pagination == "cursor" || req.Cursor != "" {
+		results, meta, err := h.Service.GetByCursor(c.Request().Context(), req)
+		if err != nil {
+			log.Warn().Err(err).Msg("handler::GetUsers - Service returned error")
+			code, errs := errmsg.Errors(err, &req)
+			return c.JSON(code, response.Error(errs))
+		}
+		return c.JSON(http.StatusOK, response.SuccessWithMeta(results, meta, "Users retrieved successfully"))
+	}
+
+	results, meta, err := h.Service.Get(c.Request().Context(), req)
 	if err != nil {
 		log.Warn().Err(err).Msg("handler::GetUsers - Service returned error")
-		return c.JSON(500, response.Error("Failed to retrieve users"))
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
 	}
 
-	return c.JSON(http.StatusOK, response.Success(results, "Users retrieved successfully"))
+	return c.JSON(http.StatusOK, response.SuccessWithMeta(results, meta, "Users retrieved successfully"))
 }
 
 func (h *UserHandler) GetUserById(c echo.Context) error {
@@ -74,3 +103,125 @@ func (h *UserHandler) GetUserById(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, response.Success(result, "User retrieved successfully"))
 }
+
+func (h *UserHandler) UpdateUser(c echo.Context) error {
+	id := c.Param("id")
+	if !utils.IsValidUUID(id) {
+		log.Warn().Msg("handler::UpdateUser - Invalid User ID format")
+		return c.JSON(http.StatusBadRequest, response.Error("Invalid User ID format"))
+	}
+
+	var req dto.UserUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateUser - Failed to bind request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateUser - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.Update(c.Request().Context(), id, req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateUser - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "User updated successfully"))
+}
+
+// PatchUser applies a JSON merge patch (RFC 7396) to the user: members that are sent replace the
+// current values and null removes them, which fails validation for required fields. The patched
+// document is validated and stored like a PUT.
+func (h *UserHandler) PatchUser(c echo.Context) error {
+	id := c.Param("id")
+	if !utils.IsValidUUID(id) {
+		log.Warn().Msg("handler::PatchUser - Invalid User ID format")
+		return c.JSON(http.StatusBadRequest, response.Error("Invalid User ID format"))
+	}
+
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	if contentType != "" && !strings.HasPrefix(contentType, mergepatch.ContentType) && !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		log.Warn().Str("content_type", contentType).Msg("handler::PatchUser - Unsupported content type")
+		return c.JSON(http.StatusUnsupportedMediaType, response.Error("Content-Type must be "+mergepatch.ContentType))
+	}
+
+	patch, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::PatchUser - Failed to read request body")
+		return c.JSON(http.StatusBadRequest, response.Error("Failed to read request body"))
+	}
+
+	current, err := h.Service.GetById(c.Request().Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::PatchUser - Service returned error")
+		code, errs := errmsg.Errors(err, &id)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	target, _ := json.Marshal(dto.UserUpdateRequest{Email: current.Email, Role: current.Role})
+	merged, err := mergepatch.Apply(target, patch)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::PatchUser - Invalid merge patch")
+		return c.JSON(http.StatusBadRequest, response.Error("Invalid merge patch document"))
+	}
+
+	var req dto.UserUpdateRequest
+	if err = json.Unmarshal(merged, &req); err != nil {
+		log.Warn().Err(err).Msg("handler::PatchUser - Invalid merge patch")
+		return c.JSON(http.StatusBadRequest, response.Error("Invalid merge patch document"))
+	}
+
+	if err = c.Validate(&req); err != nil {
+		log.Warn().Err(err).Msg("handler::PatchUser - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	res, err := h.Service.Update(c.Request().Context(), id, req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::PatchUser - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "User updated successfully"))
+}
+
+func (h *UserHandler) DeleteUser(c echo.Context) error {
+	id := c.Param("id")
+	if !utils.IsValidUUID(id) {
+		log.Warn().Msg("handler::DeleteUser - Invalid User ID format")
+		return c.JSON(http.StatusBadRequest, response.Error("Invalid User ID format"))
+	}
+
+	res, err := h.Service.Delete(c.Request().Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::DeleteUser - Service returned error")
+		code, errs := errmsg.Errors(err, &id)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "User deleted successfully"))
+}
+
+func (h *UserHandler) RestoreUser(c echo.Context) error {
+	id := c.Param("id")
+	if !utils.IsValidUUID(id) {
+		log.Warn().Msg("handler::RestoreUser - Invalid User ID format")
+		return c.JSON(http.StatusBadRequest, response.Error("Invalid User ID format"))
+	}
+
+	res, err := h.Service.Restore(c.Request().Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::RestoreUser - Service returned error")
+		code, errs := errmsg.Errors(err, &id)
+		return c.JSON(code, response.Error(errs))
+	}
+
+	return c.JSON(http.StatusOK, response.Success(res, "User restored successfully"))
+}
@@ -3,12 +3,23 @@ package port
 import (
 	"context"
 	"echo-lite-starter/internal/entity"
+	"echo-lite-starter/pkg/pagination"
 )
 
 type UserRepository interface {
-	Get(ctx context.Context) ([]*entity.UserDB, error)
+	// Get returns one page of the users matching the filter and the total number of matches.
+	Get(ctx context.Context, filter entity.UserFilter) ([]*entity.UserDB, int, error)
+	// GetByCursor returns the rows of a keyset page, up to keyset.FetchLimit() in keyset.OrderBy
+	// order, to be trimmed with pagination.KeysetPage. Sort, Limit and Offset of the filter are ignored.
+	GetByCursor(ctx context.Context, filter entity.UserFilter, keyset pagination.Keyset) ([]*entity.UserDB, error)
 	GetById(ctx context.Context, id string) (*entity.UserDB, error)
 	FindByEmail(ctx context.Context, email string) (*entity.UserDB, error)
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	Create(ctx context.Context, user *entity.UserDB) error
+	// Update stores email, password and role of the user and refreshes updated_at.
+	Update(ctx context.Context, user *entity.UserDB) error
+	// SoftDelete sets deleted_at, the user is then ignored by every lookup.
+	SoftDelete(ctx context.Context, id string) error
+	// Restore clears deleted_at of a soft deleted user, 409 when its email was registered again meanwhile.
+	Restore(ctx context.Context, id string) error
 }
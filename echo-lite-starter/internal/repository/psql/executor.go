@@ -3,6 +3,10 @@ package psql
 import (
 	"context"
 	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
 )
 
 type DBExecutor interface {
@@ -11,3 +15,19 @@ type DBExecutor interface {
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows, so one scan function serves single and list queries.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// escapeLike escapes the LIKE wildcards in s so user input is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation"
+}
@@ -6,8 +6,11 @@ import (
 	"echo-lite-starter/internal/entity"
 	"echo-lite-starter/internal/repository/port"
 	"echo-lite-starter/pkg/errmsg"
+	"echo-lite-starter/pkg/pagination"
+	"fmt"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
+	"strings"
 )
 
 type UserRepository struct {
@@ -20,31 +23,117 @@ func NewUserRepositoryImpl(db DBExecutor) port.UserRepository {
 	}
 }
 
-func (r *UserRepository) Get(ctx context.Context) ([]*entity.UserDB, error) {
-	query := `
-		SELECT u.id, u.email, u.password, u.role
-		FROM public.users u
-		WHERE u.deleted_at IS NULL
-	`
-	rows, err := r.DB.QueryContext(ctx, query)
+const userColumns = `u.id, u.email, u.password, u.role, u.created_at`
+
+func scanUser(row rowScanner) (*entity.UserDB, error) {
+	var user entity.UserDB
+	if err := row.Scan(
+		&user.Id,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// userSortColumns whitelists the fields accepted in the sort parameter of Get.
+var userSortColumns = map[string]string{
+	"created_at": "u.created_at",
+	"email":      "u.email",
+	"role":       "u.role",
+}
+
+// userConditions returns the WHERE conditions of the filter and their arguments, shared by Get and GetByCursor.
+func userConditions(filter entity.UserFilter) ([]string, []any) {
+	conditions := []string{"u.deleted_at IS NULL"}
+	var args []any
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		conditions = append(conditions, fmt.Sprintf("u.role = $%d", len(args)))
+	}
+	if filter.Email != "" {
+		args = append(args, "%"+escapeLike(filter.Email)+"%")
+		conditions = append(conditions, fmt.Sprintf("u.email ILIKE $%d", len(args)))
+	}
+	if filter.CreatedFrom != "" {
+		args = append(args, filter.CreatedFrom)
+		conditions = append(conditions, fmt.Sprintf("u.created_at >= $%d", len(args)))
+	}
+	if filter.CreatedBefore != "" {
+		args = append(args, filter.CreatedBefore)
+		conditions = append(conditions, fmt.Sprintf("u.created_at < $%d", len(args)))
+	}
+	return conditions, args
+}
+
+func (r *UserRepository) Get(ctx context.Context, filter entity.UserFilter) ([]*entity.UserDB, int, error) {
+	sorts, err := pagination.ParseSort(filter.Sort, userSortColumns)
+	if err != nil {
+		return nil, 0, errmsg.NewCustomErrors(400, errmsg.WithErrors("sort", err.Error()))
+	}
+	if len(sorts) == 0 {
+		sorts = []pagination.Sort{{Field: "created_at", Desc: true}}
+	}
+
+	conditions, args := userConditions(filter)
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err = r.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM public.users u`+where, args...).Scan(&total); err != nil {
+		log.Error().Err(err).Msg("repo::Get - Failed to count users")
+		return nil, 0, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get users"))
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := `SELECT ` + userColumns + ` FROM public.users u` + where +
+		` ORDER BY ` + pagination.OrderBy(sorts, userSortColumns, "u.id") +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	users, err := r.list(ctx, "Get", query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (r *UserRepository) GetByCursor(ctx context.Context, filter entity.UserFilter, keyset pagination.Keyset) ([]*entity.UserDB, error) {
+	conditions, args := userConditions(filter)
+	if condition, cursorArgs := keyset.Condition("u.created_at", "u.id", len(args)+1); condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, cursorArgs...)
+	}
+
+	args = append(args, keyset.FetchLimit())
+	query := `SELECT ` + userColumns + ` FROM public.users u WHERE ` + strings.Join(conditions, " AND ") +
+		` ORDER BY ` + keyset.OrderBy("u.created_at", "u.id") +
+		fmt.Sprintf(` LIMIT $%d`, len(args))
+
+	return r.list(ctx, "GetByCursor", query, args...)
+}
+
+func (r *UserRepository) list(ctx context.Context, method string, query string, args ...any) ([]*entity.UserDB, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		log.Error().Err(err).Msg("repo::Get - Failed to get users")
+		log.Error().Err(err).Msgf("repo::%s - Failed to get users", method)
 		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get users"))
 	}
 	defer rows.Close()
 
-	var users []*entity.UserDB
+	users := make([]*entity.UserDB, 0)
 	for rows.Next() {
-		var user entity.UserDB
-		if err = rows.Scan(&user.Id, &user.Email, &user.Password, &user.Role); err != nil {
-			log.Error().Err(err).Msg("repo::Get - Failed to scan user")
+		user, err := scanUser(rows)
+		if err != nil {
+			log.Error().Err(err).Msgf("repo::%s - Failed to scan user", method)
 			return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to scan user"))
 		}
-		users = append(users, &user)
+		users = append(users, user)
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Error().Err(err).Msg("repo::Get - Rows error")
+		log.Error().Err(err).Msgf("repo::%s - Rows error", method)
 		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Rows error"))
 	}
 
@@ -52,22 +141,10 @@ func (r *UserRepository) Get(ctx context.Context) ([]*entity.UserDB, error) {
 }
 
 func (r *UserRepository) GetById(ctx context.Context, id string) (*entity.UserDB, error) {
-	var user entity.UserDB
-	// your implementation here
-	query := `
-		SELECT u.id, u.email, u.password, u.role
-		FROM public.users u
-		WHERE u.id = $1 AND u.deleted_at IS NULL
-		LIMIT 1
-	`
+	query := `SELECT ` + userColumns + ` FROM public.users u WHERE u.id = $1 AND u.deleted_at IS NULL LIMIT 1`
 
-	if err := r.DB.QueryRowContext(ctx, query, id).
-		Scan(
-			&user.Id,
-			&user.Email,
-			&user.Password,
-			&user.Role,
-		); err != nil {
+	user, err := scanUser(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Error().Err(err).Str("id", id).Msg("repo::GetById - User not found")
 			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
@@ -75,26 +152,14 @@ func (r *UserRepository) GetById(ctx context.Context, id string) (*entity.UserDB
 		log.Error().Err(err).Str("id", id).Msg("repo::GetById - Failed to get user by ID")
 		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get user by ID"))
 	}
-	return &user, nil
+	return user, nil
 }
 
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.UserDB, error) {
-	var user entity.UserDB
-	// your implementation here
-	query := `
-		SELECT u.id, u.email, u.password, u.role
-		FROM public.users u
-		WHERE u.email = $1 AND u.deleted_at IS NULL
-		LIMIT 1
-	`
+	query := `SELECT ` + userColumns + ` FROM public.users u WHERE u.email = $1 AND u.deleted_at IS NULL LIMIT 1`
 
-	if err := r.DB.QueryRowContext(ctx, query, email).
-		Scan(
-			&user.Id,
-			&user.Email,
-			&user.Password,
-			&user.Role,
-		); err != nil {
+	user, err := scanUser(r.DB.QueryRowContext(ctx, query, email))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			log.Error().Err(err).Str("email", email).Msg("repo::FindByEmail - User not found")
 			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
@@ -103,7 +168,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity
 		return nil, err
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 func (r *UserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
@@ -141,3 +206,65 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.UserDB) error
 	}
 	return nil
 }
+
+func (r *UserRepository) Update(ctx context.Context, user *entity.UserDB) error {
+	query := `
+		UPDATE public.users
+		SET email = $2, password = $3, role = $4, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, user.Id, user.Email, user.Password, user.Role)
+	if err != nil {
+		if isUniqueViolation(err) {
+			log.Warn().Str("id", user.Id).Msg("repo::Update - Email is used by another user")
+			return errmsg.NewCustomErrors(409, errmsg.WithErrors("email", "Email is already used by another user"))
+		}
+		log.Error().Err(err).Str("id", user.Id).Msg("repo::Update - Failed to update user")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user"))
+	}
+	return checkUserAffected(result, user.Id, "Update", "Failed to update user")
+}
+
+func (r *UserRepository) SoftDelete(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.users
+		SET deleted_at = now(), updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::SoftDelete - Failed to delete user")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to delete user"))
+	}
+	return checkUserAffected(result, id, "SoftDelete", "Failed to delete user")
+}
+
+func (r *UserRepository) Restore(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.users
+		SET deleted_at = NULL, updated_at = now()
+		WHERE id = $1 AND deleted_at IS NOT NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			log.Warn().Str("id", id).Msg("repo::Restore - Email is used by another user")
+			return errmsg.NewCustomErrors(409, errmsg.WithErrors("email", "Email is already used by another user"))
+		}
+		log.Error().Err(err).Str("id", id).Msg("repo::Restore - Failed to restore user")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to restore user"))
+	}
+	return checkUserAffected(result, id, "Restore", "Failed to restore user")
+}
+
+// checkUserAffected turns an UPDATE that matched no user into a 404.
+func checkUserAffected(result sql.Result, id, method, failMsg string) error {
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		log.Error().Err(err).Str("id", id).Msgf("repo::%s - Failed to check rows affected", method)
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage(failMsg))
+	} else if rowsAffected != 1 {
+		log.Warn().Str("id", id).Int64("rowsAffected", rowsAffected).Msgf("repo::%s - User not found", method)
+		return errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.UserNotFound))
+	}
+	return nil
+}
@@ -17,6 +17,10 @@ func RegisterUserRoutes(g *echo.Group, repo port.RepositoryRegistry) {
 	g.POST("", userHandler.CreateUser)
 	g.GET("", userHandler.GetUsers)
 	g.GET("/:id", userHandler.GetUserById)
+	g.PUT("/:id", userHandler.UpdateUser)
+	g.PATCH("/:id", userHandler.PatchUser)
+	g.DELETE("/:id", userHandler.DeleteUser)
+	g.POST("/:id/restore", userHandler.RestoreUser)
 
 	g.Any("/*", func(c echo.Context) error {
 		log.Info().
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.users_email_active_key;
+ALTER TABLE public.users ADD CONSTRAINT users_email_key UNIQUE (email);
\ No newline at end of file
@@ -0,0 +1,48 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var columns = map[string]string{
+	"created_at": "u.created_at",
+	"email":      "u.email",
+}
+
+func TestPage(t *testing.T) {
+	page, limit := Page(0, 0)
+	assert.Equal(t, 1, page)
+	assert.Equal(t, DefaultLimit, limit)
+
+	page, limit = Page(3, 1000)
+	assert.Equal(t, 3, page)
+	assert.Equal(t, MaxLimit, limit)
+	assert.Equal(t, 200, Offset(page, limit))
+}
+
+func TestNewMeta(t *testing.T) {
+	assert.Equal(t, Meta{Page: 1, Limit: 20, Total: 41, TotalPages: 3}, NewMeta(1, 20, 41))
+	assert.Equal(t, 0, NewMeta(1, 20, 0).TotalPages)
+}
+
+func TestParseSort(t *testing.T) {
+	sorts, err := ParseSort("-created_at, email,-created_at", columns)
+	require.NoError(t, err)
+	assert.Equal(t, []Sort{{Field: "created_at", Desc: true}, {Field: "email"}}, sorts)
+
+	sorts, err = ParseSort("", columns)
+	require.NoError(t, err)
+	assert.Empty(t, sorts)
+
+	_, err = ParseSort("password", columns)
+	assert.Error(t, err)
+}
+
+func TestOrderBy(t *testing.T) {
+	sorts := []Sort{{Field: "email"}, {Field: "created_at", Desc: true}}
+	assert.Equal(t, "u.email ASC, u.created_at DESC, u.id DESC", OrderBy(sorts, columns, "u.id"))
+	assert.Equal(t, "u.id ASC", OrderBy(nil, columns, "u.id"))
+}
@@ -17,6 +17,13 @@ func Success(data any, message string) Response {
 	}
 }
 
+// SuccessWithMeta is Success with a meta block next to data, e.g. the pagination of a list.
+func SuccessWithMeta(data any, meta any, message string) Response {
+	res := Success(data, message)
+	res["meta"] = meta
+	return res
+}
+
 func Error(errorMsg any) Response {
 	if _, ok := errorMsg.(string); ok {
 		return Response{
@@ -5,8 +5,15 @@ import (
 	"fiber-jwt-starter/internal/repository/psql"
 	"fiber-jwt-starter/internal/routes"
 	"fiber-jwt-starter/middleware"
+	"fiber-jwt-starter/pkg/authcookie"
+	"fiber-jwt-starter/pkg/authz"
+	"fiber-jwt-starter/pkg/clientip"
 	dbconfig "fiber-jwt-starter/pkg/db"
+	"fiber-jwt-starter/pkg/jwthandler"
 	"fiber-jwt-starter/pkg/logging"
+	"fiber-jwt-starter/pkg/password"
+	"fiber-jwt-starter/pkg/rbac"
+	"fiber-jwt-starter/pkg/revocation"
 	"fiber-jwt-starter/pkg/validator"
 
 	"github.com/gofiber/fiber/v2"
@@ -21,6 +28,7 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 )
@@ -36,6 +44,12 @@ func main() {
 	}
 	logging.SetupLogger(config.Envs.App.Environment, config.Envs.App.LogFile, logLevel)
 
+	// Load JWT signing key
+	if err = jwthandler.Setup(); err != nil {
+		log.Fatal().Err(err).Msg("main:: failed to load JWT signing key")
+	}
+	jwthandler.WatchKeyDir(time.Minute)
+
 	// Init DB
 	db, err := dbconfig.NewPostgresConnection()
 	if err != nil {
@@ -44,9 +58,14 @@ func main() {
 	defer db.Close()
 
 	// Create Fiber app
-	app := fiber.New(fiber.Config{
+	fiberConfig := fiber.Config{
 		AppName: config.Envs.App.Name,
-	})
+	}
+	// Client IP for rate limits and the login guard, the proxy header is only trusted from known proxies
+	if err = clientip.Apply(&fiberConfig, config.Envs.App.TrustedProxies, config.Envs.App.ProxyHeader); err != nil {
+		log.Fatal().Err(err).Msg("main:: invalid APP_TRUSTED_PROXIES")
+	}
+	app := fiber.New(fiberConfig)
 
 	// Middleware
 	// Application Middlewares
@@ -57,11 +76,17 @@ func main() {
 		}))
 	}
 
-	app.Use(cors.New(cors.Config{
+	corsConfig := cors.Config{
 		AllowOrigins: "*",
 		AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
-		AllowHeaders: "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization,x-api-key",
-	}))
+		AllowHeaders: "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization,x-api-key," + config.Envs.Cookie.CsrfHeader,
+	}
+	// Cookie mode: browsers only send credentials to explicitly allowed frontend origins
+	if origins := authcookie.AllowedOrigins(); authcookie.Enabled() && len(origins) > 0 {
+		corsConfig.AllowOrigins = strings.Join(origins, ",")
+		corsConfig.AllowCredentials = true
+	}
+	app.Use(cors.New(corsConfig))
 	app.Use(middleware.ValidatorMiddleware(validator.NewValidator()))
 	app.Use(compress.New())
 	app.Use(requestid.New())
@@ -75,10 +100,34 @@ func main() {
 	//	app.Use(logger.New())
 	//}
 
+	repoRegistry := psql.NewRepositoryRegistry(db)
+
+	// Token revocation store (default: in-memory)
+	if config.Envs.Guard.RevocationStore == "postgres" {
+		revocation.SetStore(repoRegistry.GetRevokedTokenRepository())
+	}
+
+	// Permission resolver for middleware.RequirePermission (user_roles -> role_permissions)
+	rbac.SetResolver(rbac.NewCache(repoRegistry.GetRoleRepository(), time.Duration(config.Envs.Auth.PermissionCacheSeconds)*time.Second))
+
+	// Attribute/ownership policy for authz.Authorize
+	policy, err := authz.Load(config.Envs.Authz.PolicyFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("main:: failed to load authorization policy")
+	}
+	authz.SetPolicy(policy)
+
+	// Leaked password check for password.IsBreached, loaded once and required when configured
+	if file := config.Envs.Password.BreachedFile; file != "" {
+		breached, err := password.LoadBreached(file)
+		if err != nil {
+			log.Fatal().Err(err).Msg("main:: failed to load PASSWORD_BREACHED_FILE")
+		}
+		password.SetBreached(breached)
+	}
+
 	// Register routes
-	routeRegistry := routes.NewRouteRegistry(
-		psql.NewRepositoryRegistry(db),
-	)
+	routeRegistry := routes.NewRouteRegistry(repoRegistry)
 	routeRegistry.RegisterRoutes(app)
 
 	// Run server in goroutine
@@ -24,9 +24,13 @@ type Config struct {
 		LogLevel    string `env:"APP_LOG_LEVEL" env-default:"debug" required:"true"`
 		LogFile     string `env:"APP_LOG_FILE" env-default:"./logs/app.log" required:"true"`
 		BinDir      string `env:"APP_BIN_DIR" required:"true"`
+		// comma separated CIDRs of reverse proxies whose ProxyHeader is trusted, empty uses the peer address
+		TrustedProxies string `env:"APP_TRUSTED_PROXIES" required:"false"`
+		ProxyHeader    string `env:"APP_PROXY_HEADER" env-default:"X-Real-IP" required:"false"` // must be overwritten by the proxy
 	}
 	APIKeys struct {
-		XApiKey string `env:"X_API_KEY" required:"true"`
+		XApiKey            string `env:"X_API_KEY" required:"false"`                                       // legacy shared key, accepted next to the keys in api_keys when set
+		RateLimitPerMinute int    `env:"API_KEY_RATE_LIMIT_PER_MINUTE" env-default:"600" required:"false"` // per API client, 0 disables
 	}
 	DB struct {
 		Postgres struct {
@@ -43,9 +47,80 @@ type Config struct {
 		}
 	}
 	Guard struct {
-		JwtSecret         string `env:"JWT_SECRET" required:"true"`
-		JwtTtlHours       int    `env:"JWT_TTL_HOURS" env-default:"24" required:"true"`        // 24 hours
-		JwtRefreshTtlDays int    `env:"JWT_REFRESH_TTL_DAYS" env-default:"30" required:"true"` // 30 days
+		JwtAlgorithm      string `env:"JWT_ALGORITHM" env-default:"HS256" required:"true"`         // HS256 | RS256 | ES256 | EdDSA
+		JwtSecret         string `env:"JWT_SECRET" required:"false"`                               // required for HS256
+		JwtPrivateKeyFile string `env:"JWT_PRIVATE_KEY_FILE" required:"false"`                     // PEM file, required for RS256/ES256/EdDSA
+		JwtKeyID          string `env:"JWT_KEY_ID" required:"false"`                               // kid header, derived from the key when empty
+		JwtPreviousKeys   string `env:"JWT_PREVIOUS_KEYS" required:"false"`                        // kid:ALG:secret-or-pem-path,... still accepted for verification
+		JwtKeyDir         string `env:"JWT_KEY_DIR" required:"false"`                              // key directory managed by cmd/keyring, overrides the keys above
+		JwtTtlHours       int    `env:"JWT_TTL_HOURS" env-default:"24" required:"true"`            // 24 hours
+		JwtRefreshTtlDays int    `env:"JWT_REFRESH_TTL_DAYS" env-default:"30" required:"true"`     // 30 days
+		RevocationStore   string `env:"JWT_REVOCATION_STORE" env-default:"memory" required:"true"` // memory | postgres
+	}
+	Auth struct {
+		PasswordResetURL           string `env:"PASSWORD_RESET_URL" env-default:"http://localhost:3000/reset-password" required:"true"` // link sent by email, token is appended as ?token=
+		PasswordResetTtlMinutes    int    `env:"PASSWORD_RESET_TTL_MINUTES" env-default:"30" required:"true"`
+		EmailVerificationURL       string `env:"EMAIL_VERIFICATION_URL" env-default:"http://localhost:3000/verify-email" required:"true"` // token is appended as ?token=
+		EmailVerificationTtlHours  int    `env:"EMAIL_VERIFICATION_TTL_HOURS" env-default:"24" required:"true"`
+		RequireEmailVerification   bool   `env:"AUTH_REQUIRE_EMAIL_VERIFICATION" env-default:"false" required:"false"` // refuse login for unverified accounts
+		TokenResendCooldownSeconds int    `env:"AUTH_TOKEN_RESEND_COOLDOWN_SECONDS" env-default:"60" required:"true"`  // minimum gap between emailed tokens per user
+		TokenMaxPerHour            int    `env:"AUTH_TOKEN_MAX_PER_HOUR" env-default:"5" required:"true"`              // emailed tokens per user per hour
+		MfaRequiredRoles           string `env:"AUTH_MFA_REQUIRED_ROLES" required:"false"`                             // comma separated roles that must complete MFA, e.g. admin
+		MfaPendingTtlMinutes       int    `env:"AUTH_MFA_PENDING_TTL_MINUTES" env-default:"5" required:"true"`         // lifetime of the mfa_pending token between both login steps
+		LockoutThreshold           int    `env:"AUTH_LOCKOUT_THRESHOLD" env-default:"5" required:"true"`               // consecutive failures before an account is locked
+		LockoutBaseSeconds         int    `env:"AUTH_LOCKOUT_BASE_SECONDS" env-default:"30" required:"true"`           // first lock duration, doubled on every further failure
+		LockoutMaxMinutes          int    `env:"AUTH_LOCKOUT_MAX_MINUTES" env-default:"60" required:"true"`
+		IPLockoutThreshold         int    `env:"AUTH_IP_LOCKOUT_THRESHOLD" env-default:"20" required:"true"`                     // consecutive failures per client IP
+		DefaultRole                string `env:"AUTH_DEFAULT_ROLE" env-default:"user" required:"true"`                           // role granted to newly registered users
+		PermissionCacheSeconds     int    `env:"AUTH_PERMISSION_CACHE_SECONDS" env-default:"30" required:"false"`                // how long resolved permissions are cached, 0 disables
+		MagicLinkEnabled           bool   `env:"AUTH_MAGIC_LINK_ENABLED" env-default:"false" required:"false"`                   // passwordless login by emailed link
+		MagicLinkURL               string `env:"MAGIC_LINK_URL" env-default:"http://localhost:3000/magic-login" required:"true"` // token is appended as ?token=
+		MagicLinkTtlMinutes        int    `env:"MAGIC_LINK_TTL_MINUTES" env-default:"10" required:"true"`
+		Scopes                     string `env:"AUTH_SCOPES" env-default:"profile,account,sessions,mfa,admin" required:"false"` // comma separated scopes a user token can be limited to at login/refresh, any scope when empty
+	}
+	Oidc struct {
+		ProvidersFile   string `env:"OIDC_PROVIDERS_FILE" required:"false"` // JSON array of providers, OIDC login is disabled when empty
+		StateTtlMinutes int    `env:"OIDC_STATE_TTL_MINUTES" env-default:"10" required:"true"`
+		LinkByEmail     bool   `env:"OIDC_LINK_BY_EMAIL" env-default:"true" required:"false"` // link to an existing user when the provider verified the same email
+		AutoRegister    bool   `env:"OIDC_AUTO_REGISTER" env-default:"true" required:"false"` // create a user for unknown identities
+	}
+	Password struct {
+		HashAlgorithm     string `env:"PASSWORD_HASH_ALGORITHM" env-default:"argon2id" required:"true"` // argon2id | bcrypt, hashes of the other algorithm or weaker settings are upgraded at login
+		Argon2MemoryKiB   int    `env:"PASSWORD_ARGON2_MEMORY_KIB" env-default:"65536" required:"true"`
+		Argon2Iterations  int    `env:"PASSWORD_ARGON2_ITERATIONS" env-default:"3" required:"true"`
+		Argon2Parallelism int    `env:"PASSWORD_ARGON2_PARALLELISM" env-default:"2" required:"true"`
+		BcryptCost        int    `env:"PASSWORD_BCRYPT_COST" env-default:"12" required:"true"`
+		MinLength         int    `env:"PASSWORD_MIN_LENGTH" env-default:"12" required:"true"`
+		MaxLength         int    `env:"PASSWORD_MAX_LENGTH" env-default:"128" required:"true"` // keep at most 72 bytes when PASSWORD_HASH_ALGORITHM=bcrypt
+		RequireUpper      bool   `env:"PASSWORD_REQUIRE_UPPER" env-default:"true" required:"false"`
+		RequireLower      bool   `env:"PASSWORD_REQUIRE_LOWER" env-default:"true" required:"false"`
+		RequireDigit      bool   `env:"PASSWORD_REQUIRE_DIGIT" env-default:"true" required:"false"`
+		RequireSymbol     bool   `env:"PASSWORD_REQUIRE_SYMBOL" env-default:"false" required:"false"`
+		BannedSubstrings  string `env:"PASSWORD_BANNED_SUBSTRINGS" required:"false"`            // comma separated, the email local-part is always banned
+		BreachedFile      string `env:"PASSWORD_BREACHED_FILE" required:"false"`                // SHA-1 list file (hashes or prefixes, one per line) or directory of HIBP range files
+		HistorySize       int    `env:"PASSWORD_HISTORY_SIZE" env-default:"5" required:"false"` // last N passwords that cannot be reused, 0 disables
+	}
+	Cookie struct {
+		Enabled        bool   `env:"AUTH_COOKIE_ENABLED" env-default:"false" required:"false"` // hand tokens to browser clients as HttpOnly cookies instead of the response body
+		AccessName     string `env:"AUTH_COOKIE_ACCESS_NAME" env-default:"access_token" required:"false"`
+		RefreshName    string `env:"AUTH_COOKIE_REFRESH_NAME" env-default:"refresh_token" required:"false"`
+		RefreshPath    string `env:"AUTH_COOKIE_REFRESH_PATH" env-default:"/api/auth" required:"false"` // the refresh cookie is only sent to the auth endpoints
+		Domain         string `env:"AUTH_COOKIE_DOMAIN" required:"false"`
+		Secure         bool   `env:"AUTH_COOKIE_SECURE" env-default:"true" required:"false"`  // disable only for plain http development
+		SameSite       string `env:"AUTH_COOKIE_SAMESITE" env-default:"lax" required:"false"` // lax | strict | none (requires secure)
+		CsrfName       string `env:"AUTH_CSRF_COOKIE_NAME" env-default:"csrf_token" required:"false"`
+		CsrfHeader     string `env:"AUTH_CSRF_HEADER" env-default:"X-CSRF-Token" required:"false"`
+		AllowedOrigins string `env:"AUTH_COOKIE_ALLOWED_ORIGINS" required:"false"` // comma separated frontend origins allowed to send credentials (CORS)
+	}
+	OAuth struct {
+		ClientTokenTtlMinutes int `env:"OAUTH_CLIENT_TOKEN_TTL_MINUTES" env-default:"60" required:"true"` // lifetime of client_credentials access tokens
+	}
+	Authz struct {
+		PolicyFile string `env:"AUTHZ_POLICY_FILE" required:"false"` // JSON rules for pkg/authz, built-in admin + owner policy when empty
+	}
+	Notifier struct {
+		Driver  string `env:"NOTIFIER_DRIVER" env-default:"log" required:"true"` // log | file
+		FileDir string `env:"NOTIFIER_FILE_DIR" env-default:"./storage/mail" required:"true"`
 	}
 }
 
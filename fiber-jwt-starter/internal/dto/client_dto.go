@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type ClientCreateRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"omitempty,dive,required"`
+}
+
+// ClientUpdateRequest only changes the fields that are present in the body.
+type ClientUpdateRequest struct {
+	Name   *string   `json:"name" validate:"omitempty,min=1,max=100"`
+	Scopes *[]string `json:"scopes" validate:"omitempty,dive,required"`
+}
+
+type ClientResponse struct {
+	ID         string     `json:"id"` // client_id
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ClientSecretResponse carries the plain secret, it is only returned on create and rotate.
+type ClientSecretResponse struct {
+	ClientResponse
+	ClientSecret string `json:"client_secret"`
+}
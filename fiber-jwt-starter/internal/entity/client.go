@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// ClientDB is a machine client allowed to obtain access tokens with the client_credentials grant.
+type ClientDB struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	SecretHash string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
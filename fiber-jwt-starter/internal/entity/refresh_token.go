@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type RefreshTokenDB struct {
+	Id        string     `json:"id"`
+	UserId    string     `json:"user_id"`
+	FamilyId  string     `json:"family_id"`
+	TokenHash string     `json:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RotatedAt *time.Time `json:"rotated_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type RoleDB struct {
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"` // permission names granted through role_permissions
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PermissionDB struct {
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
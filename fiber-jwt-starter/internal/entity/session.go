@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// SessionDB is one login (device) of a user, tied to the refresh token family issued at that login.
+type SessionDB struct {
+	Id         string     `json:"id"`
+	UserId     string     `json:"user_id"`
+	FamilyId   string     `json:"family_id"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposeMagicLink         = "magic_link"
+	TokenPurposePasswordReset     = "password_reset"
+)
+
+type UserTokenDB struct {
+	Id        string     `json:"id"`
+	UserId    string     `json:"user_id"`
+	Purpose   string     `json:"purpose"`
+	TokenHash string     `json:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/service"
+	"fiber-jwt-starter/middleware"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type AdminRoleHandler struct {
+	Service service.AdminRoleService
+}
+
+func NewAdminRoleHandler(service service.AdminRoleService) *AdminRoleHandler {
+	return &AdminRoleHandler{Service: service}
+}
+
+func (h *AdminRoleHandler) ListRoles(c *fiber.Ctx) error {
+	res, err := h.Service.ListRoles(c.Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::AdminRole.ListRoles - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Role berhasil dimuat"))
+}
+
+func (h *AdminRoleHandler) CreateRole(c *fiber.Ctx) error {
+	var req dto.RoleCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.CreateRole - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.CreateRole - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.CreateRole(c.Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::AdminRole.CreateRole - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Success(res, "Role berhasil dibuat"))
+}
+
+func (h *AdminRoleHandler) SetRolePermissions(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	var req dto.RolePermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.SetRolePermissions - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.SetRolePermissions - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.SetRolePermissions(c.Context(), name, req)
+	if err != nil {
+		log.Warn().Err(err).Str("role", name).Msg("handler::AdminRole.SetRolePermissions - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Permission role berhasil diperbarui"))
+}
+
+func (h *AdminRoleHandler) ListPermissions(c *fiber.Ctx) error {
+	res, err := h.Service.ListPermissions(c.Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::AdminRole.ListPermissions - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Permission berhasil dimuat"))
+}
+
+func (h *AdminRoleHandler) UserRoles(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.UserRoles(c.Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminRole.UserRoles - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Role user berhasil dimuat"))
+}
+
+func (h *AdminRoleHandler) GrantRole(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req dto.UserRoleGrantRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.GrantRole - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminRole.GrantRole - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.GrantRole(c.Context(), id, req, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminRole.GrantRole - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Role berhasil diberikan"))
+}
+
+func (h *AdminRoleHandler) RevokeRole(c *fiber.Ctx) error {
+	id := c.Params("id")
+	role := c.Params("role")
+
+	res, err := h.Service.RevokeRole(c.Context(), id, role, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Str("role", role).Msg("handler::AdminRole.RevokeRole - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Role berhasil dicabut"))
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/service"
+	"fiber-jwt-starter/middleware"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type AdminUserHandler struct {
+	Service service.AdminUserService
+}
+
+func NewAdminUserHandler(service service.AdminUserService) *AdminUserHandler {
+	return &AdminUserHandler{Service: service}
+}
+
+func (h *AdminUserHandler) List(c *fiber.Ctx) error {
+	var req dto.AdminUserListRequest
+	if err := c.QueryParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminUser.List - Failed to parse query")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminUser.List - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.List(c.Context(), req, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::AdminUser.List - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "User berhasil dimuat"))
+}
+
+func (h *AdminUserHandler) Get(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.Get(c.Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Get - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "User berhasil dimuat"))
+}
+
+func (h *AdminUserHandler) UpdateRole(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req dto.AdminUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminUser.UpdateRole - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::AdminUser.UpdateRole - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.UpdateRole(c.Context(), id, req, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.UpdateRole - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Role user berhasil diubah"))
+}
+
+func (h *AdminUserHandler) Disable(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.Disable(c.Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Disable - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Akun berhasil dinonaktifkan"))
+}
+
+func (h *AdminUserHandler) Enable(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.Enable(c.Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Enable - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Akun berhasil diaktifkan"))
+}
+
+func (h *AdminUserHandler) Delete(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.Service.Delete(c.Context(), id, middleware.GetSubjectFromContext(c)); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Delete - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Akun berhasil dihapus"))
+}
+
+func (h *AdminUserHandler) Restore(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.Restore(c.Context(), id, middleware.GetSubjectFromContext(c))
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Restore - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Akun berhasil dipulihkan"))
+}
+
+func (h *AdminUserHandler) Unlock(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.Service.Unlock(c.Context(), id, middleware.GetSubjectFromContext(c)); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::AdminUser.Unlock - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Akun berhasil dibuka"))
+}
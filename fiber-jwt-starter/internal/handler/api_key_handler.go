@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/service"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type ApiKeyHandler struct {
+	Service service.ApiKeyService
+}
+
+func NewApiKeyHandler(service service.ApiKeyService) *ApiKeyHandler {
+	return &ApiKeyHandler{Service: service}
+}
+
+func (h *ApiKeyHandler) List(c *fiber.Ctx) error {
+	res, err := h.Service.List(c.Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ApiKey.List - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "API key berhasil dimuat"))
+}
+
+func (h *ApiKeyHandler) Get(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.Get(c.Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::ApiKey.Get - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "API key berhasil dimuat"))
+}
+
+func (h *ApiKeyHandler) Create(c *fiber.Ctx) error {
+	var req dto.ApiKeyCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ApiKey.Create - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ApiKey.Create - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.Create(c.Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ApiKey.Create - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Success(res, "API key berhasil dibuat, simpan key ini karena tidak akan ditampilkan lagi"))
+}
+
+func (h *ApiKeyHandler) Update(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req dto.ApiKeyUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ApiKey.Update - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ApiKey.Update - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.Update(c.Context(), id, req)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::ApiKey.Update - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "API key berhasil diperbarui"))
+}
+
+func (h *ApiKeyHandler) Rotate(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.Rotate(c.Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::ApiKey.Rotate - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "API key berhasil dirotasi, simpan key ini karena tidak akan ditampilkan lagi"))
+}
+
+func (h *ApiKeyHandler) Revoke(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.Service.Revoke(c.Context(), id); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::ApiKey.Revoke - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "API key berhasil dicabut"))
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/middleware"
+	"fiber-jwt-starter/pkg/authcookie"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) Profile(c *fiber.Ctx) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.Status(http.StatusUnauthorized).JSON(response.Error("Unauthorized"))
+	}
+
+	res, err := h.Service.Profile(c.Context(), claims)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::Profile - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Profile loaded"))
+}
+
+func (h *AuthHandler) UpdateProfile(c *fiber.Ctx) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.Status(http.StatusUnauthorized).JSON(response.Error("Unauthorized"))
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::UpdateProfile - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::UpdateProfile - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.UpdateProfile(c.Context(), claims, req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateProfile - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Profil berhasil diperbarui"))
+}
+
+func (h *AuthHandler) ChangePassword(c *fiber.Ctx) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.Status(http.StatusUnauthorized).JSON(response.Error("Unauthorized"))
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ChangePassword - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ChangePassword - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.Service.ChangePassword(c.Context(), claims, req); err != nil {
+		log.Warn().Err(err).Msg("handler::ChangePassword - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Password berhasil diubah, sesi di perangkat lain telah diakhiri"))
+}
+
+func (h *AuthHandler) DeleteAccount(c *fiber.Ctx) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.Status(http.StatusUnauthorized).JSON(response.Error("Unauthorized"))
+	}
+
+	if err := h.Service.DeleteAccount(c.Context(), claims); err != nil {
+		log.Warn().Err(err).Msg("handler::DeleteAccount - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if authcookie.Enabled() {
+		for _, cookie := range authcookie.ClearCookies() {
+			setCookie(c, cookie)
+		}
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Akun berhasil dihapus"))
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"fiber-jwt-starter/config"
 	"fiber-jwt-starter/internal/dto"
 	"fiber-jwt-starter/internal/service"
 	"fiber-jwt-starter/middleware"
+	"fiber-jwt-starter/pkg/authcookie"
 	"fiber-jwt-starter/pkg/errmsg"
 	"fiber-jwt-starter/pkg/response"
 	"github.com/gofiber/fiber/v2"
@@ -35,6 +37,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	req.ClientInfo = clientInfo(c)
 	res, err := h.Service.Login(c.Context(), req)
 	if err != nil {
 		log.Warn().Err(err).Msg("handler::Login - Service returned error")
@@ -42,23 +45,42 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
 	return c.Status(http.StatusOK).JSON(response.Success(res, "Login berhasil"))
 }
 
 func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
-	authHeader := c.Get("Authorization")
-	if !strings.HasPrefix(authHeader, "Bearer ") {
+	tokenStr := refreshToken(c)
+	if tokenStr == "" {
 		return c.Status(fiber.StatusUnauthorized).JSON(response.Error("Unauthorized"))
 	}
 
-	tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-	res, err := h.Service.RefreshToken(c.Context(), tokenStr)
+	// the body is optional, it only carries a narrower scope
+	var req dto.RefreshRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			log.Info().Err(err).Msg("handler::Refresh - Failed to parse request body")
+			code, errs := errmsg.Errors(err, &req)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+	}
+
+	res, err := h.Service.RefreshToken(c.Context(), tokenStr, req)
 	if err != nil {
 		log.Warn().Err(err).Msg("handler::Refresh - Service returned error")
 		code, errs := errmsg.Errors(err, &tokenStr)
 		return c.Status(code).JSON(response.Error(errs))
 	}
 
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
 	return c.Status(http.StatusOK).JSON(response.Success(res, "Access token refreshed"))
 }
 
@@ -87,13 +109,177 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	return c.Status(http.StatusCreated).JSON(response.Success(res, "Registrasi berhasil"))
 }
 
-func (h *AuthHandler) Profile(c *fiber.Ctx) error {
-	userId := middleware.GetUserIDFromContext(c)
-	role := middleware.GetRoleFromContext(c)
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(response.Error("Unauthorized"))
+	}
+
+	if err := h.Service.Logout(c.Context(), claims); err != nil {
+		log.Warn().Err(err).Msg("handler::Logout - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if authcookie.Enabled() {
+		for _, cookie := range authcookie.ClearCookies() {
+			setCookie(c, cookie)
+		}
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Logout berhasil"))
+}
+
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ForgotPassword - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ForgotPassword - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.Service.ForgotPassword(c.Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::ForgotPassword - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Jika email terdaftar, link reset password telah dikirim"))
+}
+
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ResetPassword - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ResetPassword - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.Service.ResetPassword(c.Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::ResetPassword - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Password berhasil direset, silakan login kembali"))
+}
+
+func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req dto.VerifyEmailRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyEmail - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyEmail - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.Service.VerifyEmail(c.Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::VerifyEmail - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Email berhasil diverifikasi"))
+}
+
+func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
+	var req dto.ResendVerificationRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ResendVerification - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ResendVerification - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.Service.ResendVerification(c.Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::ResendVerification - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Jika email terdaftar dan belum terverifikasi, link verifikasi telah dikirim"))
+}
+
+// refreshToken returns the refresh token of the Authorization header, falling back to the refresh cookie in cookie mode.
+func refreshToken(c *fiber.Ctx) string {
+	if authHeader := c.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	if authcookie.Enabled() {
+		return c.Cookies(config.Envs.Cookie.RefreshName)
+	}
+	return ""
+}
+
+// tokenResponse sets the token cookies in cookie mode and removes the tokens from the response body,
+// so browser clients never expose them to JavaScript.
+func tokenResponse(c *fiber.Ctx, res dto.LoginResponse) (dto.LoginResponse, error) {
+	if !authcookie.Enabled() || res.AccessToken == "" {
+		return res, nil
+	}
+
+	cookies, err := authcookie.TokenCookies(res.AccessToken, res.RefreshToken)
+	if err != nil {
+		log.Error().Err(err).Msg("handler::tokenResponse - Failed to create session cookies")
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(http.StatusInternalServerError, errmsg.WithMessage("Gagal membuat cookie sesi"))
+	}
+	for _, cookie := range cookies {
+		setCookie(c, cookie)
+	}
+
+	return dto.LoginResponse{MfaRequired: res.MfaRequired, MfaToken: res.MfaToken}, nil
+}
 
-	data := map[string]string{
-		"user_id": userId,
-		"role":    role,
+// setCookie writes a cookie built by pkg/authcookie to the response.
+func setCookie(c *fiber.Ctx, cookie *http.Cookie) {
+	sameSite := fiber.CookieSameSiteLaxMode
+	switch cookie.SameSite {
+	case http.SameSiteStrictMode:
+		sameSite = fiber.CookieSameSiteStrictMode
+	case http.SameSiteNoneMode:
+		sameSite = fiber.CookieSameSiteNoneMode
 	}
-	return c.Status(http.StatusOK).JSON(response.Success(data, "Profile loaded"))
+
+	c.Cookie(&fiber.Cookie{
+		Name:     cookie.Name,
+		Value:    cookie.Value,
+		Path:     cookie.Path,
+		Domain:   cookie.Domain,
+		MaxAge:   cookie.MaxAge,
+		Expires:  cookie.Expires,
+		Secure:   cookie.Secure,
+		HTTPOnly: cookie.HttpOnly,
+		SameSite: sameSite,
+	})
+}
+
+// clientInfo returns the IP and User-Agent of the device making the request.
+func clientInfo(c *fiber.Ctx) dto.ClientInfo {
+	return dto.ClientInfo{IP: c.IP(), UserAgent: c.Get(fiber.HeaderUserAgent)}
 }
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) RequestMagicLink(c *fiber.Ctx) error {
+	var req dto.MagicLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::RequestMagicLink - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::RequestMagicLink - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.Service.RequestMagicLink(c.Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::RequestMagicLink - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Jika email terdaftar, link login telah dikirim"))
+}
+
+func (h *AuthHandler) VerifyMagicLink(c *fiber.Ctx) error {
+	var req dto.MagicLinkVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyMagicLink - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyMagicLink - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	req.ClientInfo = clientInfo(c)
+	res, err := h.Service.VerifyMagicLink(c.Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::VerifyMagicLink - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Login berhasil"))
+}
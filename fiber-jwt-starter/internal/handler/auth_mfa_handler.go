@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/middleware"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) EnrollMfa(c *fiber.Ctx) error {
+	userId := middleware.GetUserIDFromContext(c)
+
+	res, err := h.Service.EnrollMfa(c.Context(), userId)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::EnrollMfa - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Scan QR code lalu konfirmasi dengan kode dari aplikasi authenticator"))
+}
+
+func (h *AuthHandler) ConfirmMfa(c *fiber.Ctx) error {
+	var req dto.MfaCodeRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ConfirmMfa - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::ConfirmMfa - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.ConfirmMfa(c.Context(), middleware.GetUserIDFromContext(c), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ConfirmMfa - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "MFA aktif, simpan recovery code di tempat yang aman"))
+}
+
+func (h *AuthHandler) VerifyMfa(c *fiber.Ctx) error {
+	var req dto.MfaVerifyRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyMfa - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::VerifyMfa - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	req.ClientInfo = clientInfo(c)
+	res, err := h.Service.VerifyMfa(c.Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::VerifyMfa - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Login berhasil"))
+}
+
+func (h *AuthHandler) DisableMfa(c *fiber.Ctx) error {
+	var req dto.MfaCodeRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::DisableMfa - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::DisableMfa - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := h.Service.DisableMfa(c.Context(), middleware.GetUserIDFromContext(c), req); err != nil {
+		log.Warn().Err(err).Msg("handler::DisableMfa - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "MFA dinonaktifkan"))
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/pkg/errmsg"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// Introspect answers RFC 7662 token introspection, the response is not wrapped in response.Success.
+func (h *AuthHandler) Introspect(c *fiber.Ctx) error {
+	var req dto.IntrospectRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::Introspect - Failed to parse request body")
+		return c.Status(http.StatusBadRequest).JSON(dto.OAuthErrorResponse{Error: "invalid_request"})
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::Introspect - Validation failed")
+		return c.Status(http.StatusBadRequest).JSON(dto.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "token is required"})
+	}
+
+	res, err := h.Service.Introspect(c.Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::Introspect - Service returned error")
+		code, _ := errmsg.Errors[any](err)
+		return c.Status(code).JSON(dto.OAuthErrorResponse{Error: "server_error"})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(http.StatusOK).JSON(res)
+}
+
+// RevokeToken answers RFC 7009 token revocation with an empty 200, also for unknown tokens.
+func (h *AuthHandler) RevokeToken(c *fiber.Ctx) error {
+	var req dto.RevokeTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::RevokeToken - Failed to parse request body")
+		return c.Status(http.StatusBadRequest).JSON(dto.OAuthErrorResponse{Error: "invalid_request"})
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::RevokeToken - Validation failed")
+		return c.Status(http.StatusBadRequest).JSON(dto.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "token is required"})
+	}
+
+	if err := h.Service.RevokeToken(c.Context(), req); err != nil {
+		log.Warn().Err(err).Msg("handler::RevokeToken - Service returned error")
+		code, _ := errmsg.Errors[any](err)
+		return c.Status(code).JSON(dto.OAuthErrorResponse{Error: "server_error"})
+	}
+
+	return c.SendStatus(http.StatusOK)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) OidcProviders(c *fiber.Ctx) error {
+	return c.Status(http.StatusOK).JSON(response.Success(h.Service.OidcProviders(), "Daftar provider OIDC"))
+}
+
+func (h *AuthHandler) OidcAuthorize(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+
+	res, err := h.Service.OidcAuthorize(c.Context(), provider)
+	if err != nil {
+		log.Warn().Err(err).Str("provider", provider).Msg("handler::OidcAuthorize - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Arahkan user ke authorization_url"))
+}
+
+func (h *AuthHandler) OidcCallback(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+
+	var req dto.OidcCallbackRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OidcCallback - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OidcCallback - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	req.ClientInfo = clientInfo(c)
+	res, err := h.Service.OidcCallback(c.Context(), provider, req)
+	if err != nil {
+		log.Warn().Err(err).Str("provider", provider).Msg("handler::OidcCallback - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if res, err = tokenResponse(c, res); err != nil {
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Login berhasil"))
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fiber-jwt-starter/middleware"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *AuthHandler) ListSessions(c *fiber.Ctx) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.Status(http.StatusUnauthorized).JSON(response.Error("Unauthorized"))
+	}
+
+	res, err := h.Service.ListSessions(c.Context(), claims)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::ListSessions - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Sesi berhasil dimuat"))
+}
+
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.Status(http.StatusUnauthorized).JSON(response.Error("Unauthorized"))
+	}
+
+	id := c.Params("id")
+	if err := h.Service.RevokeSession(c.Context(), claims, id); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::RevokeSession - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Sesi berhasil diakhiri"))
+}
+
+func (h *AuthHandler) RevokeOtherSessions(c *fiber.Ctx) error {
+	claims := middleware.GetClaimsFromContext(c)
+	if claims == nil {
+		return c.Status(http.StatusUnauthorized).JSON(response.Error("Unauthorized"))
+	}
+
+	res, err := h.Service.RevokeOtherSessions(c.Context(), claims)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::RevokeOtherSessions - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Semua sesi lain berhasil diakhiri"))
+}
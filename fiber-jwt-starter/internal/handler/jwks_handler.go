@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"fiber-jwt-starter/pkg/jwthandler"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+)
+
+type JWKSHandler struct{}
+
+func NewJWKSHandler() *JWKSHandler {
+	return &JWKSHandler{}
+}
+
+// GetJWKS serves the public verification keys as a standard JWK Set (not wrapped in response.Success).
+func (h *JWKSHandler) GetJWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.Status(http.StatusOK).JSON(jwthandler.JWKS())
+}
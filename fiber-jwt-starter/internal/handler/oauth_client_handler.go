@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/service"
+	"fiber-jwt-starter/middleware"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+type OAuthClientHandler struct {
+	Service service.OAuthClientService
+}
+
+func NewOAuthClientHandler(service service.OAuthClientService) *OAuthClientHandler {
+	return &OAuthClientHandler{Service: service}
+}
+
+// Token implements the client_credentials grant (RFC 6749 section 4.4), the response is not wrapped in response.Success.
+func (h *OAuthClientHandler) Token(c *fiber.Ctx) error {
+	var req dto.TokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Token - Failed to parse request body")
+		return c.Status(http.StatusBadRequest).JSON(dto.OAuthErrorResponse{Error: "invalid_request"})
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Token - Validation failed")
+		return c.Status(http.StatusBadRequest).JSON(dto.OAuthErrorResponse{Error: "invalid_request", ErrorDescription: "grant_type is required"})
+	}
+	if req.GrantType != "client_credentials" {
+		return c.Status(http.StatusBadRequest).JSON(dto.OAuthErrorResponse{Error: "unsupported_grant_type"})
+	}
+
+	res, err := h.Service.IssueToken(c.Context(), middleware.GetAPIClientFromContext(c), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::OAuthClient.Token - Service returned error")
+		code, _ := errmsg.Errors[any](err)
+		if code == http.StatusBadRequest {
+			return c.Status(code).JSON(dto.OAuthErrorResponse{Error: "invalid_scope", ErrorDescription: err.Error()})
+		}
+		return c.Status(code).JSON(dto.OAuthErrorResponse{Error: "server_error"})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(http.StatusOK).JSON(res)
+}
+
+func (h *OAuthClientHandler) List(c *fiber.Ctx) error {
+	res, err := h.Service.List(c.Context())
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::OAuthClient.List - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Client berhasil dimuat"))
+}
+
+func (h *OAuthClientHandler) Get(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.Get(c.Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::OAuthClient.Get - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Client berhasil dimuat"))
+}
+
+func (h *OAuthClientHandler) Create(c *fiber.Ctx) error {
+	var req dto.ClientCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Create - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Create - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.Create(c.Context(), req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::OAuthClient.Create - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Success(res, "Client berhasil dibuat, simpan client secret ini karena tidak akan ditampilkan lagi"))
+}
+
+func (h *OAuthClientHandler) Update(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req dto.ClientUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Update - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::OAuthClient.Update - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.Update(c.Context(), id, req)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::OAuthClient.Update - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Client berhasil diperbarui"))
+}
+
+func (h *OAuthClientHandler) RotateSecret(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	res, err := h.Service.RotateSecret(c.Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::OAuthClient.RotateSecret - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "Client secret berhasil dirotasi, simpan client secret ini karena tidak akan ditampilkan lagi"))
+}
+
+func (h *OAuthClientHandler) Revoke(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.Service.Revoke(c.Context(), id); err != nil {
+		log.Warn().Err(err).Str("id", id).Msg("handler::OAuthClient.Revoke - Service returned error")
+		code, errs := errmsg.Errors[any](err)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(nil, "Client berhasil dicabut"))
+}
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/entity"
+)
+
+type ApiKeyRepository interface {
+	List(ctx context.Context) ([]entity.ApiKeyDB, error)
+	FindById(ctx context.Context, id string) (*entity.ApiKeyDB, error)
+	FindByPrefix(ctx context.Context, prefix string) (*entity.ApiKeyDB, error)
+	Create(ctx context.Context, key *entity.ApiKeyDB) error
+	// Update stores name, owner, scopes and expires_at of the key.
+	Update(ctx context.Context, key *entity.ApiKeyDB) error
+	// Rotate replaces the secret of the key, the previous secret stops working immediately.
+	Rotate(ctx context.Context, id string, prefix string, keyHash string) error
+	Revoke(ctx context.Context, id string) error
+	// TouchLastUsed records usage, at most once per minute per key.
+	TouchLastUsed(ctx context.Context, id string) error
+}
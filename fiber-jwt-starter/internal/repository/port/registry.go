@@ -7,4 +7,16 @@ type InTransaction func(ctx context.Context, repoRegistry RepositoryRegistry) (i
 type RepositoryRegistry interface {
 	DoInTransaction(ctx context.Context, txFunc InTransaction) (out interface{}, err error)
 	GetUserRepository() UserRepository
+	GetRefreshTokenRepository() RefreshTokenRepository
+	GetRevokedTokenRepository() RevokedTokenRepository
+	GetUserTokenRepository() UserTokenRepository
+	GetUserMfaRepository() UserMfaRepository
+	GetMfaRecoveryCodeRepository() MfaRecoveryCodeRepository
+	GetUserIdentityRepository() UserIdentityRepository
+	GetOidcStateRepository() OidcStateRepository
+	GetApiKeyRepository() ApiKeyRepository
+	GetRoleRepository() RoleRepository
+	GetSessionRepository() SessionRepository
+	GetPasswordHistoryRepository() PasswordHistoryRepository
+	GetClientRepository() ClientRepository
 }
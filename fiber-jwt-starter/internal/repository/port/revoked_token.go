@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// RevokedTokenRepository is the Postgres backed denylist, it satisfies revocation.Store.
+type RevokedTokenRepository interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
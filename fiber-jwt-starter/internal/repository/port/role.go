@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/pkg/rbac"
+)
+
+// RoleRepository stores roles, their permissions and the roles granted to users, it satisfies rbac.Resolver.
+type RoleRepository interface {
+	List(ctx context.Context) ([]entity.RoleDB, error)
+	FindByName(ctx context.Context, name string) (*entity.RoleDB, error)
+	Create(ctx context.Context, role *entity.RoleDB) error
+	ListPermissions(ctx context.Context) ([]entity.PermissionDB, error)
+	// SetPermissions replaces the permissions of the role, unknown permission names are ignored.
+	SetPermissions(ctx context.Context, roleId string, permissions []string) error
+	UserGrants(ctx context.Context, userId string) (rbac.Grants, error)
+	// Grant is idempotent, granting a role the user already has is not an error.
+	Grant(ctx context.Context, userId string, roleId string, grantedBy string) error
+	// Revoke reports whether the user had the role.
+	Revoke(ctx context.Context, userId string, roleId string) (bool, error)
+}
@@ -3,10 +3,32 @@ package port
 import (
 	"context"
 	"fiber-jwt-starter/internal/entity"
+	"time"
 )
 
 type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*entity.UserDB, error)
+	FindById(ctx context.Context, id string) (*entity.UserDB, error)
+	// FindByIdWithDeleted also returns soft deleted users, used by the admin API.
+	FindByIdWithDeleted(ctx context.Context, id string) (*entity.UserDB, error)
+	// List returns one page of the users matching the filter and the total number of matches.
+	List(ctx context.Context, filter entity.UserFilter) ([]entity.UserDB, int, error)
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	Create(ctx context.Context, user *entity.UserDB) error
+	UpdatePassword(ctx context.Context, id string, hashedPassword string) error
+	MarkEmailVerified(ctx context.Context, id string) error
+	// IncrementFailedLogins adds one failed login attempt and returns the consecutive failure count.
+	IncrementFailedLogins(ctx context.Context, id string) (int, error)
+	LockUntil(ctx context.Context, id string, until time.Time) error
+	// ResetFailedLogins clears the failure counter and any lock.
+	ResetFailedLogins(ctx context.Context, id string) error
+	// UpdateProfile stores name and avatar_url of the user, empty values are stored as NULL.
+	UpdateProfile(ctx context.Context, user *entity.UserDB) error
+	// SoftDelete sets deleted_at, the user is then ignored by every lookup.
+	SoftDelete(ctx context.Context, id string) error
+	UpdateRole(ctx context.Context, id string, role string) error
+	// SetDisabled sets or clears disabled_at, disabled users cannot sign in.
+	SetDisabled(ctx context.Context, id string, disabled bool) error
+	// Restore clears deleted_at of a soft deleted user, 409 when the email was registered again meanwhile.
+	Restore(ctx context.Context, id string) error
 }
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/entity"
+)
+
+type UserMfaRepository interface {
+	FindByUser(ctx context.Context, userId string) (*entity.UserMfaDB, error)
+	// SavePending stores a new, not yet confirmed secret. An already enabled secret is left untouched.
+	SavePending(ctx context.Context, userId string, secret string) error
+	Enable(ctx context.Context, userId string) error
+	Delete(ctx context.Context, userId string) error
+	// MarkStepUsed records the last accepted time step and returns false when the step was already used.
+	MarkStepUsed(ctx context.Context, userId string, step int64) (bool, error)
+}
+
+type MfaRecoveryCodeRepository interface {
+	// ReplaceAll removes the previous codes of the user and stores the new hashed codes.
+	ReplaceAll(ctx context.Context, userId string, codeHashes []string) error
+	// Use marks an unused code as used and returns false when no such code exists.
+	Use(ctx context.Context, userId string, codeHash string) (bool, error)
+	CountUnused(ctx context.Context, userId string) (int, error)
+	DeleteByUser(ctx context.Context, userId string) error
+}
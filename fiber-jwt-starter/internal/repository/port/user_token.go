@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/entity"
+	"time"
+)
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *entity.UserTokenDB) error
+	FindByHash(ctx context.Context, purpose string, tokenHash string) (*entity.UserTokenDB, error)
+	// MarkUsed returns false when the token was already used by another request.
+	MarkUsed(ctx context.Context, id string) (bool, error)
+	InvalidateByUser(ctx context.Context, userId string, purpose string) error
+	CountSince(ctx context.Context, userId string, purpose string, since time.Time) (int, error)
+}
@@ -0,0 +1,147 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type ClientRepository struct {
+	DB DBExecutor
+}
+
+func NewClientRepositoryImpl(db DBExecutor) port.ClientRepository {
+	return &ClientRepository{
+		DB: db,
+	}
+}
+
+const clientColumns = `cl.id, cl.name, cl.secret_hash, cl.scopes, cl.last_used_at, cl.revoked_at, cl.created_at, cl.updated_at`
+
+func scanClient(row rowScanner) (*entity.ClientDB, error) {
+	var client entity.ClientDB
+	if err := row.Scan(
+		&client.Id,
+		&client.Name,
+		&client.SecretHash,
+		pq.Array(&client.Scopes),
+		&client.LastUsedAt,
+		&client.RevokedAt,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *ClientRepository) List(ctx context.Context) ([]entity.ClientDB, error) {
+	query := `SELECT ` + clientColumns + ` FROM public.clients cl ORDER BY cl.created_at DESC`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		log.Error().Err(err).Msg("repo::Client.List - Failed to list clients")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list clients"))
+	}
+	defer rows.Close()
+
+	clients := make([]entity.ClientDB, 0)
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			log.Error().Err(err).Msg("repo::Client.List - Failed to scan client")
+			return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list clients"))
+		}
+		clients = append(clients, *client)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Msg("repo::Client.List - Failed to iterate clients")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list clients"))
+	}
+
+	return clients, nil
+}
+
+func (r *ClientRepository) FindById(ctx context.Context, id string) (*entity.ClientDB, error) {
+	query := `SELECT ` + clientColumns + ` FROM public.clients cl WHERE cl.id = $1 LIMIT 1`
+
+	client, err := scanClient(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.NotFound))
+		}
+		log.Error().Err(err).Str("id", id).Msg("repo::Client.FindById - Failed to get client")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get client"))
+	}
+	return client, nil
+}
+
+func (r *ClientRepository) Create(ctx context.Context, client *entity.ClientDB) error {
+	query := `
+		INSERT INTO public.clients (id, name, secret_hash, scopes)
+		VALUES ($1, $2, $3, $4);
+	`
+	if _, err := r.DB.ExecContext(ctx, query, client.Id, client.Name, client.SecretHash, pq.Array(client.Scopes)); err != nil {
+		log.Error().Err(err).Str("name", client.Name).Msg("repo::Client.Create - Failed to create client")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to create client"))
+	}
+	return nil
+}
+
+func (r *ClientRepository) Update(ctx context.Context, client *entity.ClientDB) error {
+	query := `
+		UPDATE public.clients
+		SET name = $2, scopes = $3, updated_at = now()
+		WHERE id = $1;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, client.Id, client.Name, pq.Array(client.Scopes)); err != nil {
+		log.Error().Err(err).Str("id", client.Id).Msg("repo::Client.Update - Failed to update client")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update client"))
+	}
+	return nil
+}
+
+func (r *ClientRepository) RotateSecret(ctx context.Context, id string, secretHash string) error {
+	query := `
+		UPDATE public.clients
+		SET secret_hash = $2, updated_at = now()
+		WHERE id = $1 AND revoked_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id, secretHash); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::Client.RotateSecret - Failed to rotate client secret")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to rotate client secret"))
+	}
+	return nil
+}
+
+func (r *ClientRepository) Revoke(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.clients
+		SET revoked_at = COALESCE(revoked_at, now()), updated_at = now()
+		WHERE id = $1;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::Client.Revoke - Failed to revoke client")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke client"))
+	}
+	return nil
+}
+
+func (r *ClientRepository) TouchLastUsed(ctx context.Context, id string) error {
+	query := `
+		UPDATE public.clients
+		SET last_used_at = now()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - INTERVAL '1 minute');
+	`
+	if _, err := r.DB.ExecContext(ctx, query, id); err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::Client.TouchLastUsed - Failed to update last used")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update client"))
+	}
+	return nil
+}
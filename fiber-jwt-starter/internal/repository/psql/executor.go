@@ -3,6 +3,7 @@ package psql
 import (
 	"context"
 	"database/sql"
+	"strings"
 )
 
 type DBExecutor interface {
@@ -11,3 +12,13 @@ type DBExecutor interface {
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows, so one scan function serves single and list queries.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// escapeLike escapes the LIKE wildcards in s so user input is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,81 @@
+package psql
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+
+	"github.com/rs/zerolog/log"
+)
+
+type PasswordHistoryRepository struct {
+	DB DBExecutor
+}
+
+func NewPasswordHistoryRepositoryImpl(db DBExecutor) port.PasswordHistoryRepository {
+	return &PasswordHistoryRepository{
+		DB: db,
+	}
+}
+
+func (r *PasswordHistoryRepository) Create(ctx context.Context, entry *entity.PasswordHistoryDB) error {
+	query := `
+		INSERT INTO public.password_history (id, user_id, password_hash)
+		VALUES ($1, $2, $3);
+	`
+	if _, err := r.DB.ExecContext(ctx, query, entry.Id, entry.UserId, entry.PasswordHash); err != nil {
+		log.Error().Err(err).Str("user_id", entry.UserId).Msg("repo::PasswordHistory.Create - Failed to store password history")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to store password history"))
+	}
+	return nil
+}
+
+func (r *PasswordHistoryRepository) ListRecentHashes(ctx context.Context, userId string, limit int) ([]string, error) {
+	query := `
+		SELECT ph.password_hash
+		FROM public.password_history ph
+		WHERE ph.user_id = $1
+		ORDER BY ph.created_at DESC
+		LIMIT $2
+	`
+	rows, err := r.DB.QueryContext(ctx, query, userId, limit)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::PasswordHistory.ListRecentHashes - Failed to list password history")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list password history"))
+	}
+	defer rows.Close()
+
+	hashes := make([]string, 0, limit)
+	for rows.Next() {
+		var hash string
+		if err = rows.Scan(&hash); err != nil {
+			log.Error().Err(err).Str("user_id", userId).Msg("repo::PasswordHistory.ListRecentHashes - Failed to scan password history")
+			return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list password history"))
+		}
+		hashes = append(hashes, hash)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::PasswordHistory.ListRecentHashes - Failed to iterate password history")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list password history"))
+	}
+
+	return hashes, nil
+}
+
+func (r *PasswordHistoryRepository) Prune(ctx context.Context, userId string, keep int) error {
+	query := `
+		DELETE FROM public.password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT ph.id FROM public.password_history ph
+			WHERE ph.user_id = $1
+			ORDER BY ph.created_at DESC
+			LIMIT $2
+		);
+	`
+	if _, err := r.DB.ExecContext(ctx, query, userId, keep); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::PasswordHistory.Prune - Failed to prune password history")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to prune password history"))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package psql
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RevokedTokenRepository struct {
+	DB DBExecutor
+}
+
+func NewRevokedTokenRepositoryImpl(db DBExecutor) port.RevokedTokenRepository {
+	return &RevokedTokenRepository{
+		DB: db,
+	}
+}
+
+func (r *RevokedTokenRepository) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO public.revoked_tokens (jti, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (jti) DO NOTHING;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, jti, expiresAt); err != nil {
+		log.Error().Err(err).Str("jti", jti).Msg("repo::RevokedToken.Revoke - Failed to revoke token")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke token"))
+	}
+
+	// Evict entries whose token has expired anyway
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM public.revoked_tokens WHERE expires_at < now();`); err != nil {
+		log.Warn().Err(err).Msg("repo::RevokedToken.Revoke - Failed to evict expired entries")
+	}
+	return nil
+}
+
+func (r *RevokedTokenRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM public.revoked_tokens rt
+			WHERE rt.jti = $1 AND rt.expires_at > now()
+		);
+	`
+	var revoked bool
+	if err := r.DB.QueryRowContext(ctx, query, jti).Scan(&revoked); err != nil {
+		log.Error().Err(err).Str("jti", jti).Msg("repo::RevokedToken.IsRevoked - Failed to check token revocation")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to check token revocation"))
+	}
+	return revoked, nil
+}
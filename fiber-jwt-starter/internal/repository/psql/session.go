@@ -0,0 +1,160 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type SessionRepository struct {
+	DB DBExecutor
+}
+
+func NewSessionRepositoryImpl(db DBExecutor) port.SessionRepository {
+	return &SessionRepository{
+		DB: db,
+	}
+}
+
+const sessionColumns = `s.id, s.user_id, s.family_id, s.user_agent, s.ip, s.created_at, s.last_seen_at, s.revoked_at`
+
+func scanSession(row rowScanner) (*entity.SessionDB, error) {
+	var session entity.SessionDB
+	var userAgent, ip sql.NullString
+	if err := row.Scan(
+		&session.Id,
+		&session.UserId,
+		&session.FamilyId,
+		&userAgent,
+		&ip,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+	session.UserAgent = userAgent.String
+	session.IP = ip.String
+	return &session, nil
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *entity.SessionDB) error {
+	query := `
+		INSERT INTO public.sessions (id, user_id, family_id, user_agent, ip)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''));
+	`
+	if _, err := r.DB.ExecContext(ctx, query, session.Id, session.UserId, session.FamilyId, session.UserAgent, session.IP); err != nil {
+		log.Error().Err(err).Str("user_id", session.UserId).Msg("repo::Session.Create - Failed to create session")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to create session"))
+	}
+	return nil
+}
+
+func (r *SessionRepository) FindById(ctx context.Context, id string) (*entity.SessionDB, error) {
+	query := `SELECT ` + sessionColumns + ` FROM public.sessions s WHERE s.id = $1 LIMIT 1`
+
+	session, err := scanSession(r.DB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Str("id", id).Msg("repo::Session.FindById - Session not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage("Session not found"))
+		}
+		log.Error().Err(err).Str("id", id).Msg("repo::Session.FindById - Failed to get session")
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *SessionRepository) ListActiveByUser(ctx context.Context, userId string, since time.Time) ([]entity.SessionDB, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM public.sessions s
+		WHERE s.user_id = $1 AND s.revoked_at IS NULL AND s.last_seen_at > $2
+		ORDER BY s.last_seen_at DESC
+	`
+	rows, err := r.DB.QueryContext(ctx, query, userId, since)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::Session.ListActiveByUser - Failed to list sessions")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list sessions"))
+	}
+	defer rows.Close()
+
+	sessions := make([]entity.SessionDB, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", userId).Msg("repo::Session.ListActiveByUser - Failed to scan session")
+			return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list sessions"))
+		}
+		sessions = append(sessions, *session)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::Session.ListActiveByUser - Failed to iterate sessions")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to list sessions"))
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, familyId string) error {
+	query := `
+		UPDATE public.sessions
+		SET last_seen_at = now()
+		WHERE family_id = $1 AND revoked_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, familyId); err != nil {
+		log.Error().Err(err).Str("family_id", familyId).Msg("repo::Session.Touch - Failed to update session")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update session"))
+	}
+	return nil
+}
+
+func (r *SessionRepository) RevokeByFamily(ctx context.Context, familyId string) error {
+	query := `
+		UPDATE public.sessions
+		SET revoked_at = now()
+		WHERE family_id = $1 AND revoked_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, familyId); err != nil {
+		log.Error().Err(err).Str("family_id", familyId).Msg("repo::Session.RevokeByFamily - Failed to revoke session")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke session"))
+	}
+	return nil
+}
+
+func (r *SessionRepository) RevokeByUser(ctx context.Context, userId string, exceptFamilyId string) ([]string, error) {
+	query := `
+		UPDATE public.sessions
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL AND ($2 = '' OR family_id::text <> $2)
+		RETURNING family_id;
+	`
+	rows, err := r.DB.QueryContext(ctx, query, userId, exceptFamilyId)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::Session.RevokeByUser - Failed to revoke sessions")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke sessions"))
+	}
+	defer rows.Close()
+
+	families := make([]string, 0)
+	for rows.Next() {
+		var familyId string
+		if err = rows.Scan(&familyId); err != nil {
+			log.Error().Err(err).Str("user_id", userId).Msg("repo::Session.RevokeByUser - Failed to scan family")
+			return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke sessions"))
+		}
+		families = append(families, familyId)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::Session.RevokeByUser - Failed to iterate families")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to revoke sessions"))
+	}
+
+	return families, nil
+}
@@ -0,0 +1,107 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type UserMfaRepository struct {
+	DB DBExecutor
+}
+
+func NewUserMfaRepositoryImpl(db DBExecutor) port.UserMfaRepository {
+	return &UserMfaRepository{
+		DB: db,
+	}
+}
+
+func (r *UserMfaRepository) FindByUser(ctx context.Context, userId string) (*entity.UserMfaDB, error) {
+	var mfa entity.UserMfaDB
+	query := `
+		SELECT m.user_id, m.secret, m.enabled_at, m.last_used_step, m.created_at, m.updated_at
+		FROM public.user_mfa m
+		WHERE m.user_id = $1
+		LIMIT 1
+	`
+
+	if err := r.DB.QueryRowContext(ctx, query, userId).
+		Scan(
+			&mfa.UserId,
+			&mfa.Secret,
+			&mfa.EnabledAt,
+			&mfa.LastUsedStep,
+			&mfa.CreatedAt,
+			&mfa.UpdatedAt,
+		); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.NotFound))
+		}
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::UserMfa.FindByUser - Failed to get user mfa")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get user mfa"))
+	}
+
+	return &mfa, nil
+}
+
+func (r *UserMfaRepository) SavePending(ctx context.Context, userId string, secret string) error {
+	query := `
+		INSERT INTO public.user_mfa (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = NULL, updated_at = now()
+		WHERE public.user_mfa.enabled_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, userId, secret); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::UserMfa.SavePending - Failed to store mfa secret")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to store mfa secret"))
+	}
+	return nil
+}
+
+func (r *UserMfaRepository) Enable(ctx context.Context, userId string) error {
+	query := `
+		UPDATE public.user_mfa
+		SET enabled_at = now(), updated_at = now()
+		WHERE user_id = $1 AND enabled_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, userId); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::UserMfa.Enable - Failed to enable mfa")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to enable mfa"))
+	}
+	return nil
+}
+
+func (r *UserMfaRepository) Delete(ctx context.Context, userId string) error {
+	query := `DELETE FROM public.user_mfa WHERE user_id = $1;`
+	if _, err := r.DB.ExecContext(ctx, query, userId); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::UserMfa.Delete - Failed to delete mfa")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to disable mfa"))
+	}
+	return nil
+}
+
+func (r *UserMfaRepository) MarkStepUsed(ctx context.Context, userId string, step int64) (bool, error) {
+	query := `
+		UPDATE public.user_mfa
+		SET last_used_step = $2, updated_at = now()
+		WHERE user_id = $1 AND (last_used_step IS NULL OR last_used_step < $2);
+	`
+	result, err := r.DB.ExecContext(ctx, query, userId, step)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::UserMfa.MarkStepUsed - Failed to update last used step")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update mfa"))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("repo::UserMfa.MarkStepUsed - Failed to check rows affected")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update mfa"))
+	}
+	return rowsAffected == 1, nil
+}
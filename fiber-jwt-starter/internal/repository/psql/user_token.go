@@ -0,0 +1,112 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+type UserTokenRepository struct {
+	DB DBExecutor
+}
+
+func NewUserTokenRepositoryImpl(db DBExecutor) port.UserTokenRepository {
+	return &UserTokenRepository{
+		DB: db,
+	}
+}
+
+func (r *UserTokenRepository) Create(ctx context.Context, token *entity.UserTokenDB) error {
+	query := `
+		INSERT INTO public.user_tokens (id, user_id, purpose, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+	if _, err := r.DB.ExecContext(ctx, query, token.Id, token.UserId, token.Purpose, token.TokenHash, token.ExpiresAt); err != nil {
+		log.Error().Err(err).Str("user_id", token.UserId).Str("purpose", token.Purpose).Msg("repo::UserToken.Create - Failed to store user token")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to store user token"))
+	}
+	return nil
+}
+
+func (r *UserTokenRepository) FindByHash(ctx context.Context, purpose string, tokenHash string) (*entity.UserTokenDB, error) {
+	var token entity.UserTokenDB
+	query := `
+		SELECT ut.id, ut.user_id, ut.purpose, ut.token_hash, ut.expires_at, ut.used_at, ut.created_at
+		FROM public.user_tokens ut
+		WHERE ut.token_hash = $1 AND ut.purpose = $2
+		LIMIT 1
+	`
+
+	if err := r.DB.QueryRowContext(ctx, query, tokenHash, purpose).
+		Scan(
+			&token.Id,
+			&token.UserId,
+			&token.Purpose,
+			&token.TokenHash,
+			&token.ExpiresAt,
+			&token.UsedAt,
+			&token.CreatedAt,
+		); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Str("purpose", purpose).Msg("repo::UserToken.FindByHash - User token not found")
+			return nil, errmsg.NewCustomErrors(404, errmsg.WithMessage(errmsg.NotFound))
+		}
+		log.Error().Err(err).Str("purpose", purpose).Msg("repo::UserToken.FindByHash - Failed to get user token")
+		return nil, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to get user token"))
+	}
+
+	return &token, nil
+}
+
+func (r *UserTokenRepository) MarkUsed(ctx context.Context, id string) (bool, error) {
+	query := `
+		UPDATE public.user_tokens
+		SET used_at = now()
+		WHERE id = $1 AND used_at IS NULL;
+	`
+	result, err := r.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::UserToken.MarkUsed - Failed to mark user token as used")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user token"))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("repo::UserToken.MarkUsed - Failed to check rows affected")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user token"))
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *UserTokenRepository) InvalidateByUser(ctx context.Context, userId string, purpose string) error {
+	query := `
+		UPDATE public.user_tokens
+		SET used_at = now()
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL;
+	`
+	if _, err := r.DB.ExecContext(ctx, query, userId, purpose); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Str("purpose", purpose).Msg("repo::UserToken.InvalidateByUser - Failed to invalidate user tokens")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to update user token"))
+	}
+	return nil
+}
+
+func (r *UserTokenRepository) CountSince(ctx context.Context, userId string, purpose string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(1)
+		FROM public.user_tokens ut
+		WHERE ut.user_id = $1 AND ut.purpose = $2 AND ut.created_at >= $3;
+	`
+	var count int
+	if err := r.DB.QueryRowContext(ctx, query, userId, purpose, since).Scan(&count); err != nil {
+		log.Error().Err(err).Str("user_id", userId).Str("purpose", purpose).Msg("repo::UserToken.CountSince - Failed to count user tokens")
+		return 0, errmsg.NewCustomErrors(500, errmsg.WithMessage("Failed to count user tokens"))
+	}
+	return count, nil
+}
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"fiber-jwt-starter/internal/handler"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/internal/service"
+	"fiber-jwt-starter/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterAdminRoutes(router fiber.Router, repo port.RepositoryRegistry) {
+	adminUserService := service.NewAdminUserService(repo)
+	adminUserHandler := handler.NewAdminUserHandler(adminUserService)
+	adminRoleService := service.NewAdminRoleService(repo)
+	adminRoleHandler := handler.NewAdminRoleHandler(adminRoleService)
+	apiKeyService := service.NewApiKeyService(repo)
+	apiKeyHandler := handler.NewApiKeyHandler(apiKeyService)
+	oauthClientService := service.NewOAuthClientService(repo)
+	oauthClientHandler := handler.NewOAuthClientHandler(oauthClientService)
+
+	router.Use(middleware.AuthBearer, middleware.RequireScopes("admin"), middleware.AuthRole([]string{"admin"}))
+
+	users := router.Group("/users")
+	users.Get("", middleware.RequirePermission("users:read"), adminUserHandler.List)
+	users.Get("/:id", middleware.RequirePermission("users:read"), adminUserHandler.Get)
+	users.Put("/:id/role", middleware.RequirePermission("users:write"), adminUserHandler.UpdateRole)
+	users.Post("/:id/disable", middleware.RequirePermission("users:write"), adminUserHandler.Disable)
+	users.Post("/:id/enable", middleware.RequirePermission("users:write"), adminUserHandler.Enable)
+	users.Delete("/:id", middleware.RequirePermission("users:write"), adminUserHandler.Delete)
+	users.Post("/:id/restore", middleware.RequirePermission("users:write"), adminUserHandler.Restore)
+	users.Post("/:id/unlock", middleware.RequirePermission("users:write"), adminUserHandler.Unlock)
+	users.Get("/:id/roles", middleware.RequirePermission("roles:read"), adminRoleHandler.UserRoles)
+	users.Post("/:id/roles", middleware.RequirePermission("roles:write"), adminRoleHandler.GrantRole)
+	users.Delete("/:id/roles/:role", middleware.RequirePermission("roles:write"), adminRoleHandler.RevokeRole)
+
+	roles := router.Group("/roles")
+	roles.Get("", middleware.RequirePermission("roles:read"), adminRoleHandler.ListRoles)
+	roles.Post("", middleware.RequirePermission("roles:write"), adminRoleHandler.CreateRole)
+	roles.Put("/:name/permissions", middleware.RequirePermission("roles:write"), adminRoleHandler.SetRolePermissions)
+	router.Get("/permissions", middleware.RequirePermission("roles:read"), adminRoleHandler.ListPermissions)
+
+	apiKeys := router.Group("/api-keys")
+	apiKeys.Get("", middleware.RequirePermission("api_keys:read"), apiKeyHandler.List)
+	apiKeys.Post("", middleware.RequirePermission("api_keys:write"), apiKeyHandler.Create)
+	apiKeys.Get("/:id", middleware.RequirePermission("api_keys:read"), apiKeyHandler.Get)
+	apiKeys.Patch("/:id", middleware.RequirePermission("api_keys:write"), apiKeyHandler.Update)
+	apiKeys.Post("/:id/rotate", middleware.RequirePermission("api_keys:write"), apiKeyHandler.Rotate)
+	apiKeys.Delete("/:id", middleware.RequirePermission("api_keys:write"), apiKeyHandler.Revoke)
+
+	clients := router.Group("/clients")
+	clients.Get("", middleware.RequirePermission("clients:read"), oauthClientHandler.List)
+	clients.Post("", middleware.RequirePermission("clients:write"), oauthClientHandler.Create)
+	clients.Get("/:id", middleware.RequirePermission("clients:read"), oauthClientHandler.Get)
+	clients.Patch("/:id", middleware.RequirePermission("clients:write"), oauthClientHandler.Update)
+	clients.Post("/:id/rotate", middleware.RequirePermission("clients:write"), oauthClientHandler.RotateSecret)
+	clients.Delete("/:id", middleware.RequirePermission("clients:write"), oauthClientHandler.Revoke)
+}
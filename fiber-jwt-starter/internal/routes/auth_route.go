@@ -17,10 +17,30 @@ func RegisterAuthRoutes(router fiber.Router, repo port.RepositoryRegistry) {
 	router.Post("/login", authHandler.Login)
 	router.Post("/refresh", authHandler.Refresh)
 	router.Post("/register", authHandler.Register)
+	router.Post("/logout", middleware.AuthBearer, authHandler.Logout)
+	router.Post("/password/forgot", authHandler.ForgotPassword)
+	router.Post("/password/reset", authHandler.ResetPassword)
+	router.Post("/verify-email", authHandler.VerifyEmail)
+	router.Post("/verify-email/resend", authHandler.ResendVerification)
+	router.Post("/magic/request", authHandler.RequestMagicLink)
+	router.Post("/magic/verify", authHandler.VerifyMagicLink)
+	router.Post("/mfa/verify", authHandler.VerifyMfa)
+	router.Get("/oidc/providers", authHandler.OidcProviders)
+	router.Post("/oidc/:provider/authorize", authHandler.OidcAuthorize)
+	router.Post("/oidc/:provider/callback", authHandler.OidcCallback)
+	router.Post("/mfa/enroll", middleware.AuthBearer, middleware.RequireScopes("mfa"), authHandler.EnrollMfa)
+	router.Post("/mfa/confirm", middleware.AuthBearer, middleware.RequireScopes("mfa"), authHandler.ConfirmMfa)
+	router.Post("/mfa/disable", middleware.AuthBearer, middleware.RequireScopes("mfa"), authHandler.DisableMfa)
 
 	// Protected route
 	protected := router.Group("/me", middleware.AuthBearer)
-	protected.Get("/", authHandler.Profile)
+	protected.Get("/", middleware.RequireScopes("profile"), authHandler.Profile)
+	protected.Patch("/", middleware.RequireScopes("profile"), authHandler.UpdateProfile)
+	protected.Put("/password", middleware.RequireScopes("account"), authHandler.ChangePassword)
+	protected.Delete("/", middleware.RequireScopes("account"), authHandler.DeleteAccount)
+	protected.Get("/sessions", middleware.RequireScopes("sessions"), authHandler.ListSessions)
+	protected.Delete("/sessions", middleware.RequireScopes("sessions"), authHandler.RevokeOtherSessions)
+	protected.Delete("/sessions/:id", middleware.RequireScopes("sessions"), authHandler.RevokeSession)
 
 	// Catch-all for unknown routes under /auth
 	router.All("/*", func(c *fiber.Ctx) error {
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"fiber-jwt-starter/config"
+	"fiber-jwt-starter/internal/handler"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/internal/service"
+	"fiber-jwt-starter/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterOAuthRoutes registers the token (client_credentials), RFC 7662 and RFC 7009 endpoints,
+// authenticated by client credentials instead of x-api-key.
+func RegisterOAuthRoutes(router fiber.Router, repo port.RepositoryRegistry) {
+	authHandler := handler.NewAuthHandler(service.NewAuthService(repo))
+	oauthClientService := service.NewOAuthClientService(repo)
+	oauthClientHandler := handler.NewOAuthClientHandler(oauthClientService)
+
+	// Authenticated clients are rate limited like on /api
+	clientAuth := func(scope string) []fiber.Handler {
+		handlers := []fiber.Handler{middleware.OAuthClientAuth(oauthClientService, scope)}
+		if limit := config.Envs.APIKeys.RateLimitPerMinute; limit > 0 {
+			handlers = append(handlers, middleware.APIClientRateLimiter(limit))
+		}
+		return handlers
+	}
+
+	router.Post("/token", append(clientAuth(""), oauthClientHandler.Token)...)
+	router.Post("/introspect", append(clientAuth("oauth:introspect"), authHandler.Introspect)...)
+	router.Post("/revoke", append(clientAuth("oauth:revoke"), authHandler.RevokeToken)...)
+}
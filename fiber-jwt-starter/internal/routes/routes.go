@@ -2,12 +2,14 @@ package routes
 
 import (
 	"fiber-jwt-starter/config"
+	"fiber-jwt-starter/internal/handler"
 	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/internal/service"
+	"fiber-jwt-starter/middleware"
 	"fiber-jwt-starter/pkg/response"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
-	"strings"
 )
 
 type RouteRegistry struct {
@@ -24,22 +26,32 @@ func NewRouteRegistry(repository port.RepositoryRegistry) *RouteRegistry {
 }
 
 func (r *RouteRegistry) RegisterRoutes(app *fiber.App) {
+	// Public JWKS so other services can verify our tokens (no x-api-key)
+	app.Get("/.well-known/jwks.json", handler.NewJWKSHandler().GetJWKS)
+
+	// Token introspection and revocation for other services (client credentials, no x-api-key)
+	RegisterOAuthRoutes(app.Group("/oauth"), r.Repository)
+
 	api := app.Group("/api")
 
-	// Middleware X-API-KEY
-	api.Use(func(c *fiber.Ctx) error {
-		key := c.Get("x-api-key")
-		if !strings.EqualFold(key, config.Envs.APIKeys.XApiKey) {
-			log.Error().Msg("route::RegisterRoutes - Invalid x-api-key")
-			return c.Status(fiber.StatusUnauthorized).JSON(response.Error("Unauthorized: invalid x-api-key"))
-		}
-		return c.Next()
-	})
+	// Resolve the calling API client from x-api-key (api_keys table or legacy X_API_KEY)
+	apiKeyService := service.NewApiKeyService(r.Repository)
+	api.Use(middleware.APIKeyAuth(apiKeyService))
+	if limit := config.Envs.APIKeys.RateLimitPerMinute; limit > 0 {
+		api.Use(middleware.APIClientRateLimiter(limit))
+	}
+
+	// Double-submit CSRF check for browser clients authenticated by cookie (AUTH_COOKIE_ENABLED)
+	api.Use(middleware.CSRF)
 
-	// Public routes
-	auth := api.Group("/auth")
+	// Public routes, api keys need the auth scope
+	auth := api.Group("/auth", middleware.RequireAPIScope("auth"))
 	RegisterAuthRoutes(auth, r.Repository)
 
+	// Admin routes (Bearer + role admin), api keys need the admin scope
+	admin := api.Group("/admin", middleware.RequireAPIScope("admin"))
+	RegisterAdminRoutes(admin, r.Repository)
+
 	// Contoh protected route (misal):
 	// user := api.Group("/user", middleware.AuthBearer)
 	// RegisterUserRoutes(user, r.UserHandler)
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/config"
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/authz"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/rbac"
+	"fiber-jwt-starter/pkg/utils"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AdminRoleService interface {
+	ListRoles(ctx context.Context) ([]dto.RoleResponse, error)
+	CreateRole(ctx context.Context, req dto.RoleCreateRequest) (dto.RoleResponse, error)
+	SetRolePermissions(ctx context.Context, name string, req dto.RolePermissionsRequest) (dto.RoleResponse, error)
+	ListPermissions(ctx context.Context) ([]dto.PermissionResponse, error)
+	UserRoles(ctx context.Context, userId string, actor authz.Subject) (dto.UserRolesResponse, error)
+	GrantRole(ctx context.Context, userId string, req dto.UserRoleGrantRequest, actor authz.Subject) (dto.UserRolesResponse, error)
+	RevokeRole(ctx context.Context, userId string, role string, actor authz.Subject) (dto.UserRolesResponse, error)
+}
+
+type AdminRoleServiceImpl struct {
+	cfg        *config.Config
+	repository port.RepositoryRegistry
+}
+
+func NewAdminRoleService(repo port.RepositoryRegistry) AdminRoleService {
+	return &AdminRoleServiceImpl{
+		cfg:        config.Envs,
+		repository: repo,
+	}
+}
+
+func (s *AdminRoleServiceImpl) ListRoles(ctx context.Context) ([]dto.RoleResponse, error) {
+	roles, err := s.repository.GetRoleRepository().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.RoleResponse, 0, len(roles))
+	for i := range roles {
+		res = append(res, toRoleResponse(&roles[i]))
+	}
+	return res, nil
+}
+
+func (s *AdminRoleServiceImpl) CreateRole(ctx context.Context, req dto.RoleCreateRequest) (dto.RoleResponse, error) {
+	if err := s.checkPermissionNames(ctx, req.Permissions); err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	out, err := s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		roleRepo := repo.GetRoleRepository()
+		role := &entity.RoleDB{
+			Id:          utils.GenerateID(),
+			Name:        req.Name,
+			Description: req.Description,
+		}
+		if err := roleRepo.Create(ctx, role); err != nil {
+			return nil, err
+		}
+		if err := roleRepo.SetPermissions(ctx, role.Id, req.Permissions); err != nil {
+			return nil, err
+		}
+		return roleRepo.FindByName(ctx, role.Name)
+	})
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	role := out.(*entity.RoleDB)
+	log.Info().Str("role", role.Name).Strs("permissions", role.Permissions).Msg("service::AdminRole.CreateRole - Role created")
+	return toRoleResponse(role), nil
+}
+
+func (s *AdminRoleServiceImpl) SetRolePermissions(ctx context.Context, name string, req dto.RolePermissionsRequest) (dto.RoleResponse, error) {
+	if err := s.checkPermissionNames(ctx, req.Permissions); err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	out, err := s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		roleRepo := repo.GetRoleRepository()
+		role, err := roleRepo.FindByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if err = roleRepo.SetPermissions(ctx, role.Id, req.Permissions); err != nil {
+			return nil, err
+		}
+		return roleRepo.FindByName(ctx, name)
+	})
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	// the role may be granted to anyone, drop every cached grant
+	rbac.Invalidate("")
+
+	role := out.(*entity.RoleDB)
+	log.Info().Str("role", role.Name).Strs("permissions", role.Permissions).Msg("service::AdminRole.SetRolePermissions - Role permissions updated")
+	return toRoleResponse(role), nil
+}
+
+func (s *AdminRoleServiceImpl) ListPermissions(ctx context.Context) ([]dto.PermissionResponse, error) {
+	permissions, err := s.repository.GetRoleRepository().ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.PermissionResponse, 0, len(permissions))
+	for _, p := range permissions {
+		res = append(res, dto.PermissionResponse{ID: p.Id, Name: p.Name, Description: p.Description})
+	}
+	return res, nil
+}
+
+func (s *AdminRoleServiceImpl) UserRoles(ctx context.Context, userId string, actor authz.Subject) (dto.UserRolesResponse, error) {
+	if err := s.authorizeUser(ctx, actor, "users:read", userId); err != nil {
+		return dto.UserRolesResponse{}, err
+	}
+	return s.userRoles(ctx, userId)
+}
+
+func (s *AdminRoleServiceImpl) GrantRole(ctx context.Context, userId string, req dto.UserRoleGrantRequest, actor authz.Subject) (dto.UserRolesResponse, error) {
+	if err := s.authorizeUser(ctx, actor, "users:update", userId); err != nil {
+		return dto.UserRolesResponse{}, err
+	}
+
+	if err := grantRole(ctx, s.repository, userId, req.Role, actor.ID); err != nil {
+		return dto.UserRolesResponse{}, err
+	}
+	rbac.Invalidate(userId)
+
+	log.Info().Str("user_id", userId).Str("role", req.Role).Str("granted_by", actor.ID).Msg("service::AdminRole.GrantRole - Role granted")
+	return s.userRoles(ctx, userId)
+}
+
+func (s *AdminRoleServiceImpl) RevokeRole(ctx context.Context, userId string, role string, actor authz.Subject) (dto.UserRolesResponse, error) {
+	// prevent admins from locking themselves out
+	if userId == actor.ID {
+		return dto.UserRolesResponse{}, errmsg.NewCustomErrors(http.StatusConflict, errmsg.WithMessage("Tidak dapat mencabut role milik sendiri"))
+	}
+	if err := s.authorizeUser(ctx, actor, "users:update", userId); err != nil {
+		return dto.UserRolesResponse{}, err
+	}
+
+	roleRepo := s.repository.GetRoleRepository()
+	stored, err := roleRepo.FindByName(ctx, role)
+	if err != nil {
+		return dto.UserRolesResponse{}, err
+	}
+
+	revoked, err := roleRepo.Revoke(ctx, userId, stored.Id)
+	if err != nil {
+		return dto.UserRolesResponse{}, err
+	}
+	if !revoked {
+		return dto.UserRolesResponse{}, errmsg.NewCustomErrors(http.StatusNotFound, errmsg.WithMessage("User tidak memiliki role tersebut"))
+	}
+	rbac.Invalidate(userId)
+
+	log.Info().Str("user_id", userId).Str("role", role).Str("revoked_by", actor.ID).Msg("service::AdminRole.RevokeRole - Role revoked")
+	return s.userRoles(ctx, userId)
+}
+
+// authorizeUser loads the target user and authorizes the action on it.
+func (s *AdminRoleServiceImpl) authorizeUser(ctx context.Context, actor authz.Subject, action string, userId string) error {
+	user, err := s.repository.GetUserRepository().FindById(ctx, userId)
+	if err != nil {
+		return err
+	}
+	return authz.Authorize(ctx, actor, action, userResource(user))
+}
+
+func (s *AdminRoleServiceImpl) userRoles(ctx context.Context, userId string) (dto.UserRolesResponse, error) {
+	grants, err := s.repository.GetRoleRepository().UserGrants(ctx, userId)
+	if err != nil {
+		return dto.UserRolesResponse{}, err
+	}
+	return dto.UserRolesResponse{UserID: userId, Roles: grants.Roles, Permissions: grants.Permissions}, nil
+}
+
+// checkPermissionNames rejects permission names that are not in the permissions table.
+func (s *AdminRoleServiceImpl) checkPermissionNames(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	permissions, err := s.repository.GetRoleRepository().ListPermissions(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]struct{}, len(permissions))
+	for _, p := range permissions {
+		known[p.Name] = struct{}{}
+	}
+
+	unknownErr := errmsg.NewCustomErrors(http.StatusBadRequest)
+	for _, name := range names {
+		if _, ok := known[name]; !ok {
+			unknownErr.Add("permissions", "Permission tidak dikenal: "+name)
+		}
+	}
+	if unknownErr.HasErrors() {
+		return unknownErr
+	}
+	return nil
+}
+
+func toRoleResponse(role *entity.RoleDB) dto.RoleResponse {
+	return dto.RoleResponse{
+		ID:          role.Id,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/authz"
+	"fiber-jwt-starter/pkg/errmsg"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUserRepo struct {
+	port.UserRepository
+	users   map[string]*entity.UserDB
+	deleted []string
+}
+
+func (r *fakeUserRepo) FindById(_ context.Context, id string) (*entity.UserDB, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, errmsg.NewCustomErrors(http.StatusNotFound, errmsg.WithMessage("User not found"))
+}
+
+func (r *fakeUserRepo) FindByIdWithDeleted(ctx context.Context, id string) (*entity.UserDB, error) {
+	return r.FindById(ctx, id)
+}
+
+func (r *fakeUserRepo) SoftDelete(_ context.Context, id string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func TestAdminUserPolicyDeny(t *testing.T) {
+	engine, err := authz.NewEngine(authz.Document{Rules: []authz.Rule{
+		{Name: "admin", Effect: authz.EffectAllow, Roles: []string{"admin"}, Actions: []string{"*"}, Resources: []string{"*"}},
+		{Name: "protect-admins", Effect: authz.EffectDeny, Actions: []string{"users:delete"}, Resources: []string{"user"}, Conditions: []string{"resource.role == 'admin'"}},
+	}})
+	require.NoError(t, err)
+	authz.SetPolicy(engine)
+	defer authz.SetPolicy(authz.DefaultPolicy())
+
+	users := &fakeUserRepo{users: map[string]*entity.UserDB{
+		"target": {Id: "target", Email: "target@example.com", Role: "admin"},
+	}}
+	s := &AdminUserServiceImpl{repository: &fakeRegistry{users: users}}
+	actor := authz.Subject{ID: "actor", Roles: []string{"admin"}}
+	ctx := context.Background()
+
+	res, err := s.Get(ctx, "target", actor)
+	require.NoError(t, err)
+	assert.Equal(t, "target@example.com", res.Email)
+
+	err = s.Delete(ctx, "target", actor)
+	assert.True(t, errmsg.HasCode(err, http.StatusForbidden))
+	assert.Empty(t, users.deleted)
+
+	_, err = s.Get(ctx, "target", authz.Subject{ID: "member", Roles: []string{"user"}})
+	assert.True(t, errmsg.HasCode(err, http.StatusForbidden))
+}
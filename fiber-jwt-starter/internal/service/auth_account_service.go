@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/authz"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/jwthandler"
+	"fiber-jwt-starter/pkg/utils"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// selfResource is the authz resource of the account behind the token, the built-in owner rule allows
+// every action on it. Client tokens carry no user and are denied.
+func selfResource(claims *jwthandler.CustomClaims) authz.Resource {
+	return authz.Resource{Type: "user", ID: claims.ID, OwnerID: claims.ID}
+}
+
+func passwordKey(userId string) string {
+	return "password:" + userId
+}
+
+func (s *AuthServiceImpl) Profile(ctx context.Context, claims *jwthandler.CustomClaims) (dto.ProfileResponse, error) {
+	if err := authz.Authorize(ctx, authz.SubjectFromClaims(claims), "users:read", selfResource(claims)); err != nil {
+		return dto.ProfileResponse{}, err
+	}
+
+	user, err := s.repository.GetUserRepository().FindById(ctx, claims.ID)
+	if err != nil {
+		return dto.ProfileResponse{}, err
+	}
+	return s.toProfileResponse(ctx, user)
+}
+
+func (s *AuthServiceImpl) UpdateProfile(ctx context.Context, claims *jwthandler.CustomClaims, req dto.UpdateProfileRequest) (dto.ProfileResponse, error) {
+	if err := authz.Authorize(ctx, authz.SubjectFromClaims(claims), "users:update", selfResource(claims)); err != nil {
+		return dto.ProfileResponse{}, err
+	}
+
+	userRepo := s.repository.GetUserRepository()
+	user, err := userRepo.FindById(ctx, claims.ID)
+	if err != nil {
+		return dto.ProfileResponse{}, err
+	}
+
+	// Hanya field yang dikirim yang diubah
+	if req.Name != nil {
+		user.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.AvatarURL != nil {
+		user.AvatarURL = strings.TrimSpace(*req.AvatarURL)
+	}
+	if err = userRepo.UpdateProfile(ctx, user); err != nil {
+		return dto.ProfileResponse{}, err
+	}
+
+	return s.toProfileResponse(ctx, user)
+}
+
+// ChangePassword replaces the password after checking the current one and ends every other session,
+// the session of the token used for the request stays signed in.
+func (s *AuthServiceImpl) ChangePassword(ctx context.Context, claims *jwthandler.CustomClaims, req dto.ChangePasswordRequest) error {
+	if err := authz.Authorize(ctx, authz.SubjectFromClaims(claims), "users:update", selfResource(claims)); err != nil {
+		return err
+	}
+
+	user, err := s.repository.GetUserRepository().FindById(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	// Batasi tebakan password saat ini dengan token yang dicuri
+	_, guard := loginGuards()
+	if guard.LockedFor(passwordKey(user.Id), utils.Now()) > 0 {
+		return tooManyAttemptsErr()
+	}
+	if err = s.passwords.Verify(user.Password, req.CurrentPassword); err != nil {
+		guard.Fail(passwordKey(user.Id), utils.Now())
+		return errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithErrors("current_password", "Password saat ini salah"))
+	}
+	guard.Reset(passwordKey(user.Id))
+
+	if err = s.checkNewPassword(ctx, req.Password, user.Email, user.Id); err != nil {
+		return err
+	}
+
+	hashedPassword, err := s.passwords.Hash(req.Password)
+	if err != nil {
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengenkripsi password"))
+	}
+
+	if _, err = s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		if err := repo.GetUserRepository().UpdatePassword(ctx, user.Id, hashedPassword); err != nil {
+			return nil, err
+		}
+		return nil, s.recordPassword(ctx, repo, user.Id, hashedPassword)
+	}); err != nil {
+		return err
+	}
+
+	// Akhiri sesi di perangkat lain, sesi saat ini tetap login
+	if _, err = s.RevokeOtherSessions(ctx, claims); err != nil {
+		return err
+	}
+
+	log.Info().Str("user_id", user.Id).Msg("service::ChangePassword - Password changed")
+	return nil
+}
+
+// DeleteAccount soft deletes the account (deleted_at) and ends all of its sessions.
+func (s *AuthServiceImpl) DeleteAccount(ctx context.Context, claims *jwthandler.CustomClaims) error {
+	if err := authz.Authorize(ctx, authz.SubjectFromClaims(claims), "users:delete", selfResource(claims)); err != nil {
+		return err
+	}
+
+	if _, err := s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		if err := repo.GetUserRepository().SoftDelete(ctx, claims.ID); err != nil {
+			return nil, err
+		}
+		return nil, repo.GetRefreshTokenRepository().RevokeByUser(ctx, claims.ID)
+	}); err != nil {
+		return err
+	}
+
+	// Akhiri semua sesi, termasuk access token yang sudah diterbitkan
+	families, err := s.repository.GetSessionRepository().RevokeByUser(ctx, claims.ID, "")
+	if err != nil {
+		return err
+	}
+	if err = s.revokeFamilies(ctx, families...); err != nil {
+		return err
+	}
+
+	log.Info().Str("user_id", claims.ID).Msg("service::DeleteAccount - Account deleted")
+	return nil
+}
+
+func (s *AuthServiceImpl) toProfileResponse(ctx context.Context, user *entity.UserDB) (dto.ProfileResponse, error) {
+	mfa, err := s.repository.GetUserMfaRepository().FindByUser(ctx, user.Id)
+	if err != nil && !errmsg.HasCode(err, http.StatusNotFound) {
+		return dto.ProfileResponse{}, err
+	}
+
+	return dto.ProfileResponse{
+		ID:              user.Id,
+		Email:           user.Email,
+		Name:            user.Name,
+		AvatarURL:       user.AvatarURL,
+		Role:            user.Role,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		MfaEnabled:      err == nil && mfa.EnabledAt != nil,
+		CreatedAt:       user.CreatedAt,
+	}, nil
+}
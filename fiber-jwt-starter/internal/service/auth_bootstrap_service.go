@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/utils"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// adminRole is the role seeded by the RBAC migration with every admin permission.
+const adminRole = "admin"
+
+// BootstrapAdmin creates the first admin account with a verified email, used by cmd/admin. When an
+// account with the email already exists it is promoted to admin instead and password is ignored,
+// created reports which of both happened.
+func (s *AuthServiceImpl) BootstrapAdmin(ctx context.Context, email, password string) (res dto.RegisterResponse, created bool, err error) {
+	userRepo := s.repository.GetUserRepository()
+	user, err := userRepo.FindByEmail(ctx, email)
+	if err != nil && !errmsg.HasCode(err, http.StatusNotFound) {
+		return dto.RegisterResponse{}, false, err
+	}
+
+	if err == nil {
+		if _, err = s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+			if err := repo.GetUserRepository().UpdateRole(ctx, user.Id, adminRole); err != nil {
+				return nil, err
+			}
+			return nil, grantRole(ctx, repo, user.Id, adminRole, "")
+		}); err != nil {
+			return dto.RegisterResponse{}, false, err
+		}
+
+		log.Info().Str("user_id", user.Id).Msg("service::BootstrapAdmin - Existing user promoted to admin")
+		return dto.RegisterResponse{ID: user.Id, Email: user.Email, Role: adminRole}, false, nil
+	}
+
+	if password == "" {
+		return dto.RegisterResponse{}, false, errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithErrors("password", "password harus diisi."))
+	}
+	if err = s.checkNewPassword(ctx, password, email, ""); err != nil {
+		return dto.RegisterResponse{}, false, err
+	}
+
+	hashedPassword, err := s.passwords.Hash(password)
+	if err != nil {
+		return dto.RegisterResponse{}, false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengenkripsi password"))
+	}
+
+	user = &entity.UserDB{
+		Id:       utils.GenerateID(),
+		Email:    email,
+		Password: hashedPassword,
+		Role:     adminRole,
+	}
+	if _, err = s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		userRepo := repo.GetUserRepository()
+		if err := userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		// nobody receives a verification link for the bootstrap account
+		if err := userRepo.MarkEmailVerified(ctx, user.Id); err != nil {
+			return nil, err
+		}
+		if err := s.recordPassword(ctx, repo, user.Id, hashedPassword); err != nil {
+			return nil, err
+		}
+		return nil, grantRole(ctx, repo, user.Id, adminRole, "")
+	}); err != nil {
+		return dto.RegisterResponse{}, false, err
+	}
+
+	log.Info().Str("user_id", user.Id).Msg("service::BootstrapAdmin - Admin created")
+	return dto.RegisterResponse{ID: user.Id, Email: user.Email, Role: user.Role}, true, nil
+}
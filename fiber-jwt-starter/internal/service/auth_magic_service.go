@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/notifier"
+	"fiber-jwt-starter/pkg/utils"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *AuthServiceImpl) RequestMagicLink(ctx context.Context, req dto.MagicLinkRequest) error {
+	if !s.cfg.Auth.MagicLinkEnabled {
+		return magicLinkDisabledErr()
+	}
+
+	// Selalu sukses agar tidak membocorkan email yang terdaftar
+	user, err := s.repository.GetUserRepository().FindByEmail(ctx, req.Email)
+	if err != nil {
+		log.Info().Err(err).Msg("service::RequestMagicLink - User not found, skipping")
+		return nil
+	}
+
+	// Throttle dan kegagalan kirim hanya dicatat, respons sama dengan email yang tidak terdaftar
+	if err = s.checkTokenThrottle(ctx, user.Id, entity.TokenPurposeMagicLink); err == nil {
+		err = s.sendMagicLink(ctx, user)
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", user.Id).Msg("service::RequestMagicLink - Magic link not sent")
+	}
+	return nil
+}
+
+// sendMagicLink issues a new magic link token and emails the link to the user.
+func (s *AuthServiceImpl) sendMagicLink(ctx context.Context, user *entity.UserDB) error {
+	ttl := time.Duration(s.cfg.Auth.MagicLinkTtlMinutes) * time.Minute
+	token, err := s.issueUserToken(ctx, user.Id, entity.TokenPurposeMagicLink, ttl)
+	if err != nil {
+		return err
+	}
+
+	link, err := withTokenParam(s.cfg.Auth.MagicLinkURL, token)
+	if err != nil {
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Konfigurasi MAGIC_LINK_URL tidak valid"))
+	}
+
+	if err = s.notifier.Send(ctx, notifier.Message{
+		To:      user.Email,
+		Subject: "Link login",
+		Body: fmt.Sprintf("Gunakan link berikut untuk login tanpa password:\n\n%s\n\nLink berlaku selama %d menit dan hanya dapat digunakan sekali. Abaikan email ini jika Anda tidak meminta login.",
+			link, s.cfg.Auth.MagicLinkTtlMinutes),
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.Id).Msg("service::sendMagicLink - Failed to send magic link")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengirim link login"))
+	}
+
+	return nil
+}
+
+func (s *AuthServiceImpl) VerifyMagicLink(ctx context.Context, req dto.MagicLinkVerifyRequest) (dto.LoginResponse, error) {
+	if !s.cfg.Auth.MagicLinkEnabled {
+		return dto.LoginResponse{}, magicLinkDisabledErr()
+	}
+
+	invalidErr := errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Link login tidak valid atau sudah kedaluwarsa"))
+
+	out, err := s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		token, err := s.consumeUserToken(ctx, repo, entity.TokenPurposeMagicLink, req.Token, invalidErr)
+		if err != nil {
+			return nil, err
+		}
+
+		userRepo := repo.GetUserRepository()
+		user, err := userRepo.FindById(ctx, token.UserId)
+		if err != nil {
+			return nil, invalidErr
+		}
+
+		// The link reached the inbox, so the address is verified as well
+		if user.EmailVerifiedAt == nil {
+			if err = userRepo.MarkEmailVerified(ctx, user.Id); err != nil {
+				return nil, err
+			}
+			now := utils.Now()
+			user.EmailVerifiedAt = &now
+		}
+		return user, nil
+	})
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	user := out.(*entity.UserDB)
+	// Accounts locked by failed password attempts stay locked until the lock expires or an admin unlocks them
+	if user.LockedUntil != nil && utils.Now().Before(*user.LockedUntil) {
+		return dto.LoginResponse{}, tooManyAttemptsErr()
+	}
+
+	return s.completeLogin(ctx, user, "", req.ClientInfo)
+}
+
+func magicLinkDisabledErr() error {
+	return errmsg.NewCustomErrors(http.StatusNotFound, errmsg.WithMessage("Login dengan magic link tidak aktif"))
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/jwthandler"
+	"fiber-jwt-starter/pkg/revocation"
+	"fiber-jwt-starter/pkg/totp"
+	"fiber-jwt-starter/pkg/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	mfaRecoveryCodeCount = 10
+	mfaQRCodeSize        = 256
+	mfaSkewSteps         = 1 // accept one 30s step before/after to tolerate clock drift
+)
+
+func (s *AuthServiceImpl) EnrollMfa(ctx context.Context, userId string) (dto.MfaEnrollResponse, error) {
+	user, err := s.repository.GetUserRepository().FindById(ctx, userId)
+	if err != nil {
+		return dto.MfaEnrollResponse{}, err
+	}
+
+	mfaRepo := s.repository.GetUserMfaRepository()
+	if current, err := mfaRepo.FindByUser(ctx, userId); err == nil && current.EnabledAt != nil {
+		return dto.MfaEnrollResponse{}, errmsg.NewCustomErrors(http.StatusConflict, errmsg.WithMessage("MFA sudah aktif"))
+	} else if err != nil && !errmsg.HasCode(err, http.StatusNotFound) {
+		return dto.MfaEnrollResponse{}, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return dto.MfaEnrollResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat secret MFA"))
+	}
+	if err = mfaRepo.SavePending(ctx, userId, secret); err != nil {
+		return dto.MfaEnrollResponse{}, err
+	}
+
+	uri := totp.URI(s.cfg.App.Name, user.Email, secret)
+	png, err := totp.QRCodePNG(uri, mfaQRCodeSize)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userId).Msg("service::EnrollMfa - Failed to render QR code")
+		return dto.MfaEnrollResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat QR code"))
+	}
+
+	return dto.MfaEnrollResponse{
+		Secret:     secret,
+		OtpauthURI: uri,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+func (s *AuthServiceImpl) ConfirmMfa(ctx context.Context, userId string, req dto.MfaCodeRequest) (dto.MfaRecoveryCodesResponse, error) {
+	mfa, err := s.repository.GetUserMfaRepository().FindByUser(ctx, userId)
+	if err != nil {
+		if errmsg.HasCode(err, http.StatusNotFound) {
+			return dto.MfaRecoveryCodesResponse{}, errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("MFA belum didaftarkan"))
+		}
+		return dto.MfaRecoveryCodesResponse{}, err
+	}
+	if mfa.EnabledAt != nil {
+		return dto.MfaRecoveryCodesResponse{}, errmsg.NewCustomErrors(http.StatusConflict, errmsg.WithMessage("MFA sudah aktif"))
+	}
+
+	step, ok := totp.Validate(mfa.Secret, req.Code, utils.Now(), mfaSkewSteps)
+	if !ok {
+		return dto.MfaRecoveryCodesResponse{}, errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithErrors("code", "Kode MFA tidak valid"))
+	}
+
+	codes, hashes, err := generateRecoveryCodes(mfaRecoveryCodeCount)
+	if err != nil {
+		return dto.MfaRecoveryCodesResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat recovery code"))
+	}
+
+	_, err = s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		mfaRepo := repo.GetUserMfaRepository()
+		if _, err := mfaRepo.MarkStepUsed(ctx, userId, step); err != nil {
+			return nil, err
+		}
+		if err := mfaRepo.Enable(ctx, userId); err != nil {
+			return nil, err
+		}
+		return nil, repo.GetMfaRecoveryCodeRepository().ReplaceAll(ctx, userId, hashes)
+	})
+	if err != nil {
+		return dto.MfaRecoveryCodesResponse{}, err
+	}
+
+	return dto.MfaRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *AuthServiceImpl) VerifyMfa(ctx context.Context, req dto.MfaVerifyRequest) (dto.LoginResponse, error) {
+	invalidErr := errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Token MFA tidak valid atau sudah kedaluwarsa"))
+
+	claims, err := jwthandler.ParseToken(req.MfaToken)
+	if err != nil || claims.Subject != string(jwthandler.MfaPendingToken) {
+		return dto.LoginResponse{}, invalidErr
+	}
+	if revoked, err := revocation.IsRevoked(ctx, claims.JTI()); err != nil || revoked {
+		return dto.LoginResponse{}, invalidErr
+	}
+
+	mfa, err := s.repository.GetUserMfaRepository().FindByUser(ctx, claims.ID)
+	if err != nil || mfa.EnabledAt == nil {
+		return dto.LoginResponse{}, invalidErr
+	}
+
+	// Batasi tebakan kode selama mfa_pending token berlaku
+	_, guard := loginGuards()
+	if guard.LockedFor(mfaKey(claims.ID), utils.Now()) > 0 {
+		return dto.LoginResponse{}, tooManyAttemptsErr()
+	}
+	if err = s.checkMfaCode(ctx, mfa, req.Code); err != nil {
+		if errmsg.HasCode(err, http.StatusBadRequest) {
+			guard.Fail(mfaKey(claims.ID), utils.Now())
+		}
+		return dto.LoginResponse{}, err
+	}
+	guard.Reset(mfaKey(claims.ID))
+
+	// mfa_pending token hanya boleh ditukar sekali
+	if err = revocation.Revoke(ctx, claims.JTI(), claims.ExpiresAtTime()); err != nil {
+		log.Error().Err(err).Str("jti", claims.JTI()).Msg("service::VerifyMfa - Failed to revoke mfa pending token")
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal memproses token MFA"))
+	}
+
+	return s.startSession(ctx, claims.ID, claims.Role, true, claims.Scope, req.ClientInfo)
+}
+
+func (s *AuthServiceImpl) DisableMfa(ctx context.Context, userId string, req dto.MfaCodeRequest) error {
+	mfa, err := s.repository.GetUserMfaRepository().FindByUser(ctx, userId)
+	if err != nil || mfa.EnabledAt == nil {
+		return errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("MFA belum aktif"))
+	}
+
+	if err = s.checkMfaCode(ctx, mfa, req.Code); err != nil {
+		return err
+	}
+
+	_, err = s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		if err := repo.GetMfaRecoveryCodeRepository().DeleteByUser(ctx, userId); err != nil {
+			return nil, err
+		}
+		return nil, repo.GetUserMfaRepository().Delete(ctx, userId)
+	})
+	return err
+}
+
+// issueMfaPendingToken returns the short-lived token exchanged at the second login step,
+// it carries the requested scope over to the tokens issued by VerifyMfa.
+func (s *AuthServiceImpl) issueMfaPendingToken(user *entity.UserDB, scope string) (dto.LoginResponse, error) {
+	token, err := jwthandler.GenerateToken(jwthandler.Payload{
+		ID:        user.Id,
+		Role:      user.Role,
+		Scope:     scope,
+		Subject:   jwthandler.MfaPendingToken,
+		ExpiresIn: time.Duration(s.cfg.Auth.MfaPendingTtlMinutes) * time.Minute,
+	})
+	if err != nil {
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat token MFA"))
+	}
+
+	return dto.LoginResponse{
+		MfaRequired: true,
+		MfaToken:    token,
+	}, nil
+}
+
+// checkMfaCode accepts either a TOTP code (each time step only once) or an unused recovery code.
+func (s *AuthServiceImpl) checkMfaCode(ctx context.Context, mfa *entity.UserMfaDB, code string) error {
+	invalidErr := errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithErrors("code", "Kode MFA tidak valid"))
+
+	if step, ok := totp.Validate(mfa.Secret, code, utils.Now(), mfaSkewSteps); ok {
+		fresh, err := s.repository.GetUserMfaRepository().MarkStepUsed(ctx, mfa.UserId, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			log.Warn().Str("user_id", mfa.UserId).Msg("service::checkMfaCode - TOTP code replay rejected")
+			return invalidErr
+		}
+		return nil
+	}
+
+	used, err := s.repository.GetMfaRecoveryCodeRepository().Use(ctx, mfa.UserId, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return invalidErr
+	}
+
+	log.Info().Str("user_id", mfa.UserId).Msg("service::checkMfaCode - Recovery code used")
+	return nil
+}
+
+// generateRecoveryCodes returns n random codes formatted as xxxxx-xxxxx together with their hashes.
+func generateRecoveryCodes(n int) ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, 0, n)
+	hashes := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(encoding.EncodeToString(b))[:10]
+		code := raw[:5] + "-" + raw[5:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
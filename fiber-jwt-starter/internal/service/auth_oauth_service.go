@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/jwthandler"
+	"fiber-jwt-starter/pkg/rbac"
+	"fiber-jwt-starter/pkg/revocation"
+	"fiber-jwt-starter/pkg/utils"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *AuthServiceImpl) Introspect(ctx context.Context, req dto.IntrospectRequest) (dto.IntrospectResponse, error) {
+	inactive := dto.IntrospectResponse{Active: false}
+
+	claims, err := jwthandler.ParseToken(req.Token)
+	if err != nil {
+		return inactive, nil
+	}
+	switch jwthandler.TokenType(claims.Subject) {
+	case jwthandler.AccessToken, jwthandler.RefreshToken, jwthandler.ClientToken:
+	default:
+		return inactive, nil
+	}
+
+	active, err := s.isTokenActive(ctx, req.Token, claims)
+	if err != nil || !active {
+		return inactive, err
+	}
+
+	res := dto.IntrospectResponse{
+		Active:    true,
+		Sub:       claims.ID,
+		Exp:       claims.ExpiresAtTime().Unix(),
+		Iss:       claims.Issuer,
+		Jti:       claims.JTI(),
+		Role:      claims.Role,
+		TokenType: claims.Subject,
+	}
+	if claims.IssuedAt != nil {
+		res.Iat = claims.IssuedAt.Unix()
+	}
+
+	// Client tokens carry their scope, there is no user behind them
+	if claims.Subject == string(jwthandler.ClientToken) {
+		res.Sub = claims.ClientID
+		res.ClientID = claims.ClientID
+		res.Scope = claims.Scope
+		return res, nil
+	}
+
+	// Down-scoped user tokens report their scope claim
+	if claims.Scope != "" && !slices.Contains(claims.Scopes(), jwthandler.AllScopes) {
+		res.Scope = claims.Scope
+		return res, nil
+	}
+
+	// The effective scope of an unrestricted user token are the permissions currently granted to the user
+	grants, err := rbac.UserGrants(ctx, claims.ID)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", claims.ID).Msg("service::Introspect - Failed to resolve permissions, scope omitted")
+	} else {
+		res.Scope = strings.Join(grants.Permissions, " ")
+	}
+
+	return res, nil
+}
+
+// isTokenActive checks a token with a valid signature against the revocation store and, for refresh
+// tokens, against the stored refresh token (rotated, revoked or expired tokens are inactive).
+func (s *AuthServiceImpl) isTokenActive(ctx context.Context, raw string, claims *jwthandler.CustomClaims) (bool, error) {
+	revoked, err := revocation.IsRevoked(ctx, claims.JTI())
+	if err == nil && !revoked && claims.FamilyID != "" {
+		revoked, err = revocation.IsRevoked(ctx, revocation.FamilyKey(claims.FamilyID))
+	}
+	if err != nil {
+		log.Error().Err(err).Str("jti", claims.JTI()).Msg("service::Introspect - Failed to check revocation")
+		return false, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal memeriksa status token"))
+	}
+	if revoked {
+		return false, nil
+	}
+
+	if claims.Subject != string(jwthandler.RefreshToken) {
+		return true, nil
+	}
+
+	stored, err := s.repository.GetRefreshTokenRepository().FindByHash(ctx, utils.HashToken(raw))
+	if err != nil {
+		if errmsg.HasCode(err, http.StatusNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return stored.RotatedAt == nil && stored.RevokedAt == nil && utils.Now().Before(stored.ExpiresAt), nil
+}
+
+func (s *AuthServiceImpl) RevokeToken(ctx context.Context, req dto.RevokeTokenRequest) error {
+	// Invalid, expired or unknown tokens are not an error (RFC 7009 section 2.2)
+	claims, err := jwthandler.ParseToken(req.Token)
+	if err != nil {
+		return nil
+	}
+
+	switch claims.Subject {
+	case string(jwthandler.AccessToken), string(jwthandler.ClientToken):
+		if err = revocation.Revoke(ctx, claims.JTI(), claims.ExpiresAtTime()); err != nil {
+			log.Error().Err(err).Str("jti", claims.JTI()).Msg("service::RevokeToken - Failed to revoke access token")
+			return errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mencabut access token"))
+		}
+		log.Info().Str("user_id", claims.ID).Str("client_id", claims.ClientID).Str("jti", claims.JTI()).Msg("service::RevokeToken - Access token revoked")
+
+	case string(jwthandler.RefreshToken):
+		// Revoking a refresh token ends its session, including the access tokens issued to it
+		stored, err := s.repository.GetRefreshTokenRepository().FindByHash(ctx, utils.HashToken(req.Token))
+		if err != nil {
+			if errmsg.HasCode(err, http.StatusNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err = s.revokeFamilies(ctx, stored.FamilyId); err != nil {
+			return err
+		}
+		log.Info().Str("user_id", stored.UserId).Str("family_id", stored.FamilyId).Msg("service::RevokeToken - Session revoked")
+	}
+
+	return nil
+}
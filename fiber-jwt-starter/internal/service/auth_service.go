@@ -8,45 +8,223 @@ import (
 	"fiber-jwt-starter/internal/repository/port"
 	"fiber-jwt-starter/pkg/errmsg"
 	"fiber-jwt-starter/pkg/jwthandler"
+	"fiber-jwt-starter/pkg/notifier"
+	"fiber-jwt-starter/pkg/oidc"
+	"fiber-jwt-starter/pkg/password"
+	"fiber-jwt-starter/pkg/revocation"
 	"fiber-jwt-starter/pkg/utils"
 	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
 )
 
 type AuthService interface {
 	Login(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error)
-	RefreshToken(ctx context.Context, tokenStr string) (dto.LoginResponse, error)
+	RefreshToken(ctx context.Context, tokenStr string, req dto.RefreshRequest) (dto.LoginResponse, error)
 	Register(ctx context.Context, req dto.RegisterRequest) (dto.RegisterResponse, error)
+	Logout(ctx context.Context, claims *jwthandler.CustomClaims) error
+	ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, req dto.ResendVerificationRequest) error
+	RequestMagicLink(ctx context.Context, req dto.MagicLinkRequest) error
+	VerifyMagicLink(ctx context.Context, req dto.MagicLinkVerifyRequest) (dto.LoginResponse, error)
+	EnrollMfa(ctx context.Context, userId string) (dto.MfaEnrollResponse, error)
+	ConfirmMfa(ctx context.Context, userId string, req dto.MfaCodeRequest) (dto.MfaRecoveryCodesResponse, error)
+	VerifyMfa(ctx context.Context, req dto.MfaVerifyRequest) (dto.LoginResponse, error)
+	DisableMfa(ctx context.Context, userId string, req dto.MfaCodeRequest) error
+	OidcProviders() []string
+	OidcAuthorize(ctx context.Context, provider string) (dto.OidcAuthorizeResponse, error)
+	OidcCallback(ctx context.Context, provider string, req dto.OidcCallbackRequest) (dto.LoginResponse, error)
+	ListSessions(ctx context.Context, claims *jwthandler.CustomClaims) ([]dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, claims *jwthandler.CustomClaims, id string) error
+	RevokeOtherSessions(ctx context.Context, claims *jwthandler.CustomClaims) (dto.RevokedSessionsResponse, error)
+	Profile(ctx context.Context, claims *jwthandler.CustomClaims) (dto.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, claims *jwthandler.CustomClaims, req dto.UpdateProfileRequest) (dto.ProfileResponse, error)
+	ChangePassword(ctx context.Context, claims *jwthandler.CustomClaims, req dto.ChangePasswordRequest) error
+	DeleteAccount(ctx context.Context, claims *jwthandler.CustomClaims) error
+	Introspect(ctx context.Context, req dto.IntrospectRequest) (dto.IntrospectResponse, error)
+	RevokeToken(ctx context.Context, req dto.RevokeTokenRequest) error
+	BootstrapAdmin(ctx context.Context, email, password string) (dto.RegisterResponse, bool, error)
 }
 
 type AuthServiceImpl struct {
 	cfg        *config.Config
 	repository port.RepositoryRegistry
+	notifier   notifier.Notifier
+	oidc       *oidc.Registry
+	passwords  *password.Manager
+	policy     password.Policy
+	// dummyHash is compared against for unknown emails so both cases take the same time.
+	dummyHash func() string
 }
 
 func NewAuthService(repo port.RepositoryRegistry) AuthService {
+	providers, err := oidc.LoadRegistry(config.Envs.Oidc.ProvidersFile)
+	if err != nil {
+		log.Error().Err(err).Msg("service::NewAuthService - Failed to load OIDC providers, OIDC login disabled")
+		providers, _ = oidc.NewRegistry(nil)
+	}
+
+	passwords, err := password.NewFromConfig()
+	if err != nil {
+		log.Error().Err(err).Msg("service::NewAuthService - Invalid password hashing settings, using defaults")
+		passwords, _ = password.NewManager(password.DefaultParams())
+	}
+
 	return &AuthServiceImpl{
 		cfg:        config.Envs,
 		repository: repo,
+		notifier:   notifier.NewFromConfig(),
+		oidc:       providers,
+		passwords:  passwords,
+		policy:     password.PolicyFromConfig(),
+		dummyHash: sync.OnceValue(func() string {
+			hash, _ := passwords.Hash("dummy-password-for-timing")
+			return hash
+		}),
 	}
 }
 
 func (s *AuthServiceImpl) Login(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error) {
-	// 1. Get user by email
+	// 1. Reject locked client IPs / emails before touching the database
+	if err := s.checkLoginAllowed(req.IP, req.Email); err != nil {
+		return dto.LoginResponse{}, err
+	}
+	// Unknown scopes are refused before any credential check
+	scope, err := s.resolveScope(req.Scope, nil)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	// 2. Get user by email, unknown emails get the same response as a wrong password
 	userRepo := s.repository.GetUserRepository()
 	user, err := userRepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		if !errmsg.HasCode(err, http.StatusNotFound) {
+			return dto.LoginResponse{}, err
+		}
+		_ = s.passwords.Verify(s.dummyHash(), req.Password)
+		s.recordFailedLogin(ctx, req.IP, req.Email, nil)
+		return dto.LoginResponse{}, invalidCredentialsErr()
+	}
+
+	if user.LockedUntil != nil && utils.Now().Before(*user.LockedUntil) {
+		return dto.LoginResponse{}, tooManyAttemptsErr()
+	}
+
+	// 3. Compare password
+	if err = s.passwords.Verify(user.Password, req.Password); err != nil {
+		s.recordFailedLogin(ctx, req.IP, req.Email, user)
+		return dto.LoginResponse{}, invalidCredentialsErr()
+	}
+	if err = s.recordSuccessfulLogin(ctx, req.IP, req.Email, user); err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	// 4. Upgrade hashes created with another algorithm or weaker settings while the plaintext is at hand
+	s.rehashPassword(ctx, user, req.Password)
+
+	// 5. Verification policy, MFA and token issuance
+	return s.completeLogin(ctx, user, scope, req.ClientInfo)
+}
+
+// completeLogin finishes an authenticated login (password or external identity): it refuses disabled
+// accounts, enforces the email verification policy, hands out an mfa_pending token for MFA accounts,
+// or issues the token pair.
+// scope is the already resolved scope of the tokens, empty for unrestricted tokens.
+func (s *AuthServiceImpl) completeLogin(ctx context.Context, user *entity.UserDB, scope string, client dto.ClientInfo) (dto.LoginResponse, error) {
+	// Accounts disabled by an admin cannot sign in until enabled again
+	if user.DisabledAt != nil {
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(http.StatusForbidden, errmsg.WithMessage("Akun dinonaktifkan"))
+	}
+
+	// Refuse unverified accounts when the policy is enabled
+	if s.cfg.Auth.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		return dto.LoginResponse{}, errmsg.NewCustomErrors(http.StatusForbidden, errmsg.WithMessage("Email belum diverifikasi"))
+	}
+
+	// Accounts with MFA enabled must complete the second step first
+	mfa, err := s.repository.GetUserMfaRepository().FindByUser(ctx, user.Id)
+	if err != nil && !errmsg.HasCode(err, http.StatusNotFound) {
+		return dto.LoginResponse{}, err
+	}
+	if err == nil && mfa.EnabledAt != nil {
+		return s.issueMfaPendingToken(user, scope)
+	}
+
+	// Generate tokens, starting a new session (refresh token family)
+	return s.startSession(ctx, user.Id, user.Role, false, scope, client)
+}
+
+func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken string, req dto.RefreshRequest) (dto.LoginResponse, error) {
+	invalidErr := errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Invalid refresh token"))
+
+	claims, err := jwthandler.ParseToken(refreshToken)
+	if err != nil || claims.Subject != string(jwthandler.RefreshToken) {
+		return dto.LoginResponse{}, invalidErr
+	}
+
+	// Refreshed tokens keep the scope of the refresh token, a requested scope can only narrow it
+	scope, err := s.resolveScope(req.Scope, claims)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	tokenRepo := s.repository.GetRefreshTokenRepository()
+	stored, err := tokenRepo.FindByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		return dto.LoginResponse{}, invalidErr
+	}
+
+	// Token yang sudah dirotasi/dicabut dipakai lagi: anggap dicuri, cabut seluruh family
+	if stored.RotatedAt != nil || stored.RevokedAt != nil {
+		return dto.LoginResponse{}, s.revokeReusedFamily(ctx, stored)
+	}
+
+	if utils.Now().After(stored.ExpiresAt) {
+		return dto.LoginResponse{}, invalidErr
+	}
+
+	reused := false
+	out, err := s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		rotated, err := repo.GetRefreshTokenRepository().MarkRotated(ctx, stored.Id)
+		if err != nil {
+			return nil, err
+		}
+		if !rotated {
+			reused = true
+			return nil, invalidErr
+		}
+
+		return s.issueTokens(ctx, repo, stored.UserId, claims.Role, stored.FamilyId, claims.MFA, scope)
+	})
+	if reused {
+		return dto.LoginResponse{}, s.revokeReusedFamily(ctx, stored)
+	}
 	if err != nil {
 		return dto.LoginResponse{}, err
 	}
 
-	// 2. Compare password
-	if err = utils.ComparePassword(user.Password, req.Password); err != nil {
-		return dto.LoginResponse{}, errmsg.NewCustomErrors(400, errmsg.WithMessage("Password salah"))
+	if err = s.repository.GetSessionRepository().Touch(ctx, stored.FamilyId); err != nil {
+		log.Warn().Err(err).Str("family_id", stored.FamilyId).Msg("service::RefreshToken - Failed to update session last seen")
 	}
 
-	// 3. Generate tokens
+	return out.(dto.LoginResponse), nil
+}
+
+// issueTokens generates an access/refresh token pair and persists the hashed refresh token in the given family.
+// mfa marks that the login was completed with a second factor, refreshed tokens keep the flag.
+// scope is stored in both tokens so refreshing never widens it.
+func (s *AuthServiceImpl) issueTokens(ctx context.Context, repo port.RepositoryRegistry, userId, role, familyId string, mfa bool, scope string) (dto.LoginResponse, error) {
 	accessToken, err := jwthandler.GenerateToken(jwthandler.Payload{
-		ID:              user.Id,
-		Role:            user.Role,
+		ID:              userId,
+		Role:            role,
+		FamilyID:        familyId,
+		MFA:             mfa,
+		Scope:           scope,
 		Subject:         jwthandler.AccessToken,
 		ExpirationHours: s.cfg.Guard.JwtTtlHours, // or use config.Envs.Guard.JwtTtlHours
 	})
@@ -54,43 +232,49 @@ func (s *AuthServiceImpl) Login(ctx context.Context, req dto.LoginRequest) (dto.
 		return dto.LoginResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat access token"))
 	}
 
+	refreshTtlHours := s.cfg.Guard.JwtRefreshTtlDays * 24 // Convert days to hours
+	tokenId := utils.GenerateID()
 	refreshToken, err := jwthandler.GenerateToken(jwthandler.Payload{
-		ID:              user.Id,
-		Role:            user.Role,
-		Subject:         "refresh_token",
-		ExpirationHours: s.cfg.Guard.JwtRefreshTtlDays * 24, // Convert days to hours
+		ID:              userId,
+		Role:            role,
+		TokenID:         tokenId,
+		FamilyID:        familyId,
+		MFA:             mfa,
+		Scope:           scope,
+		Subject:         jwthandler.RefreshToken,
+		ExpirationHours: refreshTtlHours,
 	})
 	if err != nil {
 		return dto.LoginResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat refresh token"))
 	}
 
-	// 4. Return response
+	if err = repo.GetRefreshTokenRepository().Create(ctx, &entity.RefreshTokenDB{
+		Id:        tokenId,
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: utils.Now().Add(time.Duration(refreshTtlHours) * time.Hour),
+	}); err != nil {
+		return dto.LoginResponse{}, err
+	}
+
 	return dto.LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
 }
 
-func (s *AuthServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (dto.LoginResponse, error) {
-	claims, err := jwthandler.ParseToken(refreshToken)
-	if err != nil || claims.Subject != string(jwthandler.RefreshToken) {
-		return dto.LoginResponse{}, errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Invalid refresh token"))
-	}
+// revokeReusedFamily ends the whole session (every refresh and access token descended from the same login) once reuse is detected.
+func (s *AuthServiceImpl) revokeReusedFamily(ctx context.Context, stored *entity.RefreshTokenDB) error {
+	log.Warn().
+		Str("user_id", stored.UserId).
+		Str("family_id", stored.FamilyId).
+		Msg("service::RefreshToken - Refresh token reuse detected, revoking token family")
 
-	accessToken, err := jwthandler.GenerateToken(jwthandler.Payload{
-		ID:              claims.ID,
-		Role:            claims.Role,
-		Subject:         jwthandler.AccessToken,
-		ExpirationHours: s.cfg.Guard.JwtTtlHours, // or use config.Envs.Guard.JwtTtlHours,
-	})
-	if err != nil {
-		return dto.LoginResponse{}, err
+	if err := s.revokeFamilies(ctx, stored.FamilyId); err != nil {
+		return err
 	}
-
-	return dto.LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken, // reuse, atau generate ulang kalau mau
-	}, nil
+	return errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Refresh token sudah digunakan, silakan login kembali"))
 }
 
 func (s *AuthServiceImpl) Register(ctx context.Context, req dto.RegisterRequest) (dto.RegisterResponse, error) {
@@ -106,26 +290,60 @@ func (s *AuthServiceImpl) Register(ctx context.Context, req dto.RegisterRequest)
 		return dto.RegisterResponse{}, errmsg.NewCustomErrors(409, errmsg.WithErrors("email", "Email sudah terdaftar"))
 	}
 
+	if err = s.checkNewPassword(ctx, req.Password, req.Email, ""); err != nil {
+		return dto.RegisterResponse{}, err
+	}
+
 	// Hash password
-	hashedPassword, err := utils.HashPassword(req.Password)
+	hashedPassword, err := s.passwords.Hash(req.Password)
 	if err != nil {
 		return dto.RegisterResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengenkripsi password"))
 	}
 
-	// Simpan user
+	// Simpan user beserta role default
 	user := &entity.UserDB{
 		Id:       utils.GenerateID(),
 		Email:    req.Email,
 		Password: hashedPassword,
-		Role:     "user",
+		Role:     s.cfg.Auth.DefaultRole,
 	}
-	if err = userRepo.Create(ctx, user); err != nil {
+	if _, err = s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		if err := repo.GetUserRepository().Create(ctx, user); err != nil {
+			return nil, err
+		}
+		if err := s.recordPassword(ctx, repo, user.Id, hashedPassword); err != nil {
+			return nil, err
+		}
+		return nil, grantRole(ctx, repo, user.Id, user.Role, "")
+	}); err != nil {
 		return dto.RegisterResponse{}, err
 	}
 
+	// Kirim link verifikasi, kegagalan tidak membatalkan registrasi (bisa dikirim ulang)
+	if err = s.sendVerificationEmail(ctx, user); err != nil {
+		log.Error().Err(err).Str("user_id", user.Id).Msg("service::Register - Failed to send verification email")
+	}
+
 	return dto.RegisterResponse{
 		ID:    user.Id,
 		Email: user.Email,
 		Role:  user.Role,
 	}, nil
 }
+
+func (s *AuthServiceImpl) Logout(ctx context.Context, claims *jwthandler.CustomClaims) error {
+	// 1. Revoke the current access token until it expires
+	if err := revocation.Revoke(ctx, claims.JTI(), claims.ExpiresAtTime()); err != nil {
+		log.Error().Err(err).Str("jti", claims.JTI()).Msg("service::Logout - Failed to revoke access token")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mencabut access token"))
+	}
+
+	// 2. End the session: its refresh token family and the other access tokens issued to it
+	if claims.FamilyID != "" {
+		if err := s.revokeFamilies(ctx, claims.FamilyID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/notifier"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *AuthServiceImpl) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error {
+	invalidErr := errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("Token verifikasi tidak valid atau sudah kedaluwarsa"))
+
+	_, err := s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		token, err := s.consumeUserToken(ctx, repo, entity.TokenPurposeEmailVerification, req.Token, invalidErr)
+		if err != nil {
+			return nil, err
+		}
+		return nil, repo.GetUserRepository().MarkEmailVerified(ctx, token.UserId)
+	})
+	return err
+}
+
+func (s *AuthServiceImpl) ResendVerification(ctx context.Context, req dto.ResendVerificationRequest) error {
+	// Selalu sukses untuk email yang tidak terdaftar atau sudah terverifikasi
+	user, err := s.repository.GetUserRepository().FindByEmail(ctx, req.Email)
+	if err != nil {
+		log.Info().Err(err).Msg("service::ResendVerification - User not found, skipping")
+		return nil
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	// Throttle dan kegagalan kirim hanya dicatat agar status akun tidak terbaca dari respons
+	if err = s.checkTokenThrottle(ctx, user.Id, entity.TokenPurposeEmailVerification); err == nil {
+		err = s.sendVerificationEmail(ctx, user)
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", user.Id).Msg("service::ResendVerification - Verification link not sent")
+	}
+	return nil
+}
+
+// sendVerificationEmail issues a new verification token and emails the link to the user.
+func (s *AuthServiceImpl) sendVerificationEmail(ctx context.Context, user *entity.UserDB) error {
+	ttl := time.Duration(s.cfg.Auth.EmailVerificationTtlHours) * time.Hour
+	token, err := s.issueUserToken(ctx, user.Id, entity.TokenPurposeEmailVerification, ttl)
+	if err != nil {
+		return err
+	}
+
+	link, err := withTokenParam(s.cfg.Auth.EmailVerificationURL, token)
+	if err != nil {
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Konfigurasi EMAIL_VERIFICATION_URL tidak valid"))
+	}
+
+	if err = s.notifier.Send(ctx, notifier.Message{
+		To:      user.Email,
+		Subject: "Verifikasi email",
+		Body: fmt.Sprintf("Gunakan link berikut untuk memverifikasi email Anda:\n\n%s\n\nLink berlaku selama %d jam dan hanya dapat digunakan sekali.",
+			link, s.cfg.Auth.EmailVerificationTtlHours),
+	}); err != nil {
+		log.Error().Err(err).Str("user_id", user.Id).Msg("service::sendVerificationEmail - Failed to send verification link")
+		return errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengirim link verifikasi email"))
+	}
+
+	return nil
+}
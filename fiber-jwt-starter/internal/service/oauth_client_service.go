@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"fiber-jwt-starter/config"
+	"fiber-jwt-starter/internal/dto"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/apikey"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/jwthandler"
+	"fiber-jwt-starter/pkg/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+type OAuthClientService interface {
+	apikey.Authenticator
+	IssueToken(ctx context.Context, client *apikey.Client, req dto.TokenRequest) (dto.TokenResponse, error)
+	List(ctx context.Context) ([]dto.ClientResponse, error)
+	Get(ctx context.Context, id string) (dto.ClientResponse, error)
+	Create(ctx context.Context, req dto.ClientCreateRequest) (dto.ClientSecretResponse, error)
+	Update(ctx context.Context, id string, req dto.ClientUpdateRequest) (dto.ClientResponse, error)
+	RotateSecret(ctx context.Context, id string) (dto.ClientSecretResponse, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+type OAuthClientServiceImpl struct {
+	cfg        *config.Config
+	repository port.RepositoryRegistry
+}
+
+func NewOAuthClientService(repo port.RepositoryRegistry) OAuthClientService {
+	return &OAuthClientServiceImpl{
+		cfg:        config.Envs,
+		repository: repo,
+	}
+}
+
+func (s *OAuthClientServiceImpl) Authenticate(ctx context.Context, clientID, clientSecret string) (*apikey.Client, error) {
+	invalidErr := errmsg.NewCustomErrors(http.StatusUnauthorized, errmsg.WithMessage("Autentikasi client gagal"))
+
+	// Only clients of the clients table, API keys and X_API_KEY are not client credentials
+	if _, err := uuid.Parse(clientID); err != nil {
+		return nil, invalidErr
+	}
+	clientRepo := s.repository.GetClientRepository()
+	stored, err := clientRepo.FindById(ctx, clientID)
+	if err != nil {
+		if errmsg.HasCode(err, http.StatusNotFound) {
+			return nil, invalidErr
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashToken(clientSecret)), []byte(stored.SecretHash)) != 1 {
+		return nil, invalidErr
+	}
+	if stored.RevokedAt != nil {
+		log.Warn().Str("client_id", stored.Id).Msg("service::OAuthClient.Authenticate - Revoked client used")
+		return nil, invalidErr
+	}
+
+	if err = clientRepo.TouchLastUsed(ctx, stored.Id); err != nil {
+		log.Warn().Err(err).Str("client_id", stored.Id).Msg("service::OAuthClient.Authenticate - Failed to record usage")
+	}
+
+	return &apikey.Client{ID: stored.Id, Name: stored.Name, Scopes: stored.Scopes}, nil
+}
+
+func (s *OAuthClientServiceImpl) IssueToken(ctx context.Context, client *apikey.Client, req dto.TokenRequest) (dto.TokenResponse, error) {
+	// Without an explicit scope the token gets every scope of the client
+	scopes := strings.Fields(req.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	for _, scope := range scopes {
+		if !client.HasScope(scope) {
+			return dto.TokenResponse{}, errmsg.NewCustomErrors(http.StatusBadRequest, errmsg.WithMessage("Scope "+scope+" tidak diizinkan untuk client ini"))
+		}
+	}
+	scope := strings.Join(scopes, " ")
+
+	ttl := time.Duration(s.cfg.OAuth.ClientTokenTtlMinutes) * time.Minute
+	accessToken, err := jwthandler.GenerateToken(jwthandler.Payload{
+		ClientID:  client.ID,
+		Scope:     scope,
+		Subject:   jwthandler.ClientToken,
+		ExpiresIn: ttl,
+	})
+	if err != nil {
+		return dto.TokenResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat access token"))
+	}
+
+	log.Info().Str("client_id", client.ID).Str("scope", scope).Msg("service::OAuthClient.IssueToken - Client token issued")
+	return dto.TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(ttl.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+func (s *OAuthClientServiceImpl) List(ctx context.Context) ([]dto.ClientResponse, error) {
+	clients, err := s.repository.GetClientRepository().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.ClientResponse, 0, len(clients))
+	for i := range clients {
+		res = append(res, toClientResponse(&clients[i]))
+	}
+	return res, nil
+}
+
+func (s *OAuthClientServiceImpl) Get(ctx context.Context, id string) (dto.ClientResponse, error) {
+	client, err := s.repository.GetClientRepository().FindById(ctx, id)
+	if err != nil {
+		return dto.ClientResponse{}, err
+	}
+	return toClientResponse(client), nil
+}
+
+func (s *OAuthClientServiceImpl) Create(ctx context.Context, req dto.ClientCreateRequest) (dto.ClientSecretResponse, error) {
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return dto.ClientSecretResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat client secret"))
+	}
+
+	scopes := req.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+	client := &entity.ClientDB{
+		Id:         utils.GenerateID(),
+		Name:       req.Name,
+		SecretHash: utils.HashToken(secret),
+		Scopes:     scopes,
+	}
+	clientRepo := s.repository.GetClientRepository()
+	if err = clientRepo.Create(ctx, client); err != nil {
+		return dto.ClientSecretResponse{}, err
+	}
+
+	created, err := clientRepo.FindById(ctx, client.Id)
+	if err != nil {
+		return dto.ClientSecretResponse{}, err
+	}
+
+	log.Info().Str("client_id", client.Id).Str("name", client.Name).Msg("service::OAuthClient.Create - Client created")
+	return dto.ClientSecretResponse{ClientResponse: toClientResponse(created), ClientSecret: secret}, nil
+}
+
+func (s *OAuthClientServiceImpl) Update(ctx context.Context, id string, req dto.ClientUpdateRequest) (dto.ClientResponse, error) {
+	clientRepo := s.repository.GetClientRepository()
+	client, err := clientRepo.FindById(ctx, id)
+	if err != nil {
+		return dto.ClientResponse{}, err
+	}
+
+	if req.Name != nil {
+		client.Name = *req.Name
+	}
+	if req.Scopes != nil {
+		client.Scopes = *req.Scopes
+	}
+
+	if err = clientRepo.Update(ctx, client); err != nil {
+		return dto.ClientResponse{}, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *OAuthClientServiceImpl) RotateSecret(ctx context.Context, id string) (dto.ClientSecretResponse, error) {
+	clientRepo := s.repository.GetClientRepository()
+	client, err := clientRepo.FindById(ctx, id)
+	if err != nil {
+		return dto.ClientSecretResponse{}, err
+	}
+	if client.RevokedAt != nil {
+		return dto.ClientSecretResponse{}, errmsg.NewCustomErrors(http.StatusConflict, errmsg.WithMessage("Client sudah dicabut"))
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return dto.ClientSecretResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat client secret"))
+	}
+	if err = clientRepo.RotateSecret(ctx, id, utils.HashToken(secret)); err != nil {
+		return dto.ClientSecretResponse{}, err
+	}
+
+	rotated, err := clientRepo.FindById(ctx, id)
+	if err != nil {
+		return dto.ClientSecretResponse{}, err
+	}
+
+	log.Info().Str("client_id", id).Msg("service::OAuthClient.RotateSecret - Client secret rotated")
+	return dto.ClientSecretResponse{ClientResponse: toClientResponse(rotated), ClientSecret: secret}, nil
+}
+
+func (s *OAuthClientServiceImpl) Revoke(ctx context.Context, id string) error {
+	clientRepo := s.repository.GetClientRepository()
+	if _, err := clientRepo.FindById(ctx, id); err != nil {
+		return err
+	}
+
+	log.Info().Str("client_id", id).Msg("service::OAuthClient.Revoke - Client revoked")
+	return clientRepo.Revoke(ctx, id)
+}
+
+func toClientResponse(client *entity.ClientDB) dto.ClientResponse {
+	return dto.ClientResponse{
+		ID:         client.Id,
+		Name:       client.Name,
+		Scopes:     client.Scopes,
+		LastUsedAt: client.LastUsedAt,
+		RevokedAt:  client.RevokedAt,
+		CreatedAt:  client.CreatedAt,
+	}
+}
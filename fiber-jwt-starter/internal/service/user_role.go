@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/repository/port"
+)
+
+// grantRole grants the role with the given name to the user. Callers invalidate the rbac cache once
+// the surrounding transaction is committed.
+func grantRole(ctx context.Context, repo port.RepositoryRegistry, userId, roleName, grantedBy string) error {
+	roleRepo := repo.GetRoleRepository()
+	role, err := roleRepo.FindByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	return roleRepo.Grant(ctx, userId, role.Id, grantedBy)
+}
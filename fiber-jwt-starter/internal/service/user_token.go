@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fiber-jwt-starter/internal/entity"
+	"fiber-jwt-starter/internal/repository/port"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/utils"
+	"net/http"
+	"time"
+)
+
+// checkTokenThrottle limits how often a single-use token of the given purpose can be emailed to a user.
+func (s *AuthServiceImpl) checkTokenThrottle(ctx context.Context, userId, purpose string) error {
+	tokenRepo := s.repository.GetUserTokenRepository()
+	now := utils.Now()
+
+	if cooldown := time.Duration(s.cfg.Auth.TokenResendCooldownSeconds) * time.Second; cooldown > 0 {
+		recent, err := tokenRepo.CountSince(ctx, userId, purpose, now.Add(-cooldown))
+		if err != nil {
+			return err
+		}
+		if recent > 0 {
+			return errmsg.NewCustomErrors(http.StatusTooManyRequests, errmsg.WithMessage("Terlalu sering meminta, silakan coba beberapa saat lagi"))
+		}
+	}
+
+	if s.cfg.Auth.TokenMaxPerHour > 0 {
+		hourly, err := tokenRepo.CountSince(ctx, userId, purpose, now.Add(-time.Hour))
+		if err != nil {
+			return err
+		}
+		if hourly >= s.cfg.Auth.TokenMaxPerHour {
+			return errmsg.NewCustomErrors(http.StatusTooManyRequests, errmsg.WithMessage("Batas permintaan per jam tercapai, silakan coba lagi nanti"))
+		}
+	}
+
+	return nil
+}
+
+// issueUserToken invalidates outstanding tokens of the same purpose and stores a new hashed one,
+// returning the raw token to be delivered to the user.
+func (s *AuthServiceImpl) issueUserToken(ctx context.Context, userId, purpose string, ttl time.Duration) (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal membuat token"))
+	}
+
+	_, err = s.repository.DoInTransaction(ctx, func(ctx context.Context, repo port.RepositoryRegistry) (interface{}, error) {
+		tokenRepo := repo.GetUserTokenRepository()
+		// Hanya token terbaru yang berlaku
+		if err := tokenRepo.InvalidateByUser(ctx, userId, purpose); err != nil {
+			return nil, err
+		}
+		return nil, tokenRepo.Create(ctx, &entity.UserTokenDB{
+			Id:        utils.GenerateID(),
+			UserId:    userId,
+			Purpose:   purpose,
+			TokenHash: utils.HashToken(token),
+			ExpiresAt: utils.Now().Add(ttl),
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumeUserToken marks a valid token as used and returns it, so it cannot be redeemed twice.
+func (s *AuthServiceImpl) consumeUserToken(ctx context.Context, repo port.RepositoryRegistry, purpose, rawToken string, invalidErr error) (*entity.UserTokenDB, error) {
+	tokenRepo := repo.GetUserTokenRepository()
+	token, err := tokenRepo.FindByHash(ctx, purpose, utils.HashToken(rawToken))
+	if err != nil {
+		return nil, invalidErr
+	}
+	if token.UsedAt != nil || utils.Now().After(token.ExpiresAt) {
+		return nil, invalidErr
+	}
+
+	used, err := tokenRepo.MarkUsed(ctx, token.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, invalidErr
+	}
+
+	return token, nil
+}
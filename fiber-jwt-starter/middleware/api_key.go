@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"fiber-jwt-starter/pkg/apikey"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/response"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/rs/zerolog/log"
+)
+
+// APIKeyAuth resolves the x-api-key header to the calling API client and stores it in the context.
+func APIKeyAuth(resolver apikey.Resolver) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get("x-api-key")
+		if key == "" {
+			log.Warn().Str("ip", c.IP()).Msg("middleware::APIKeyAuth - Missing x-api-key")
+			return c.Status(fiber.StatusUnauthorized).JSON(response.Error("Unauthorized: missing x-api-key"))
+		}
+
+		client, err := resolver.Resolve(c.Context(), key)
+		if err != nil {
+			log.Warn().Err(err).Str("ip", c.IP()).Msg("middleware::APIKeyAuth - Invalid x-api-key")
+			code, errs := errmsg.Errors[any](err)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+
+		log.Debug().
+			Str("api_client_id", client.ID).
+			Str("api_client", client.Name).
+			Str("path", c.Path()).
+			Msg("middleware::APIKeyAuth - api client resolved")
+
+		c.Locals("api_client", client)
+		return c.Next()
+	}
+}
+
+// GetAPIClientFromContext mengambil API client yang memanggil dari context Fiber
+func GetAPIClientFromContext(c *fiber.Ctx) *apikey.Client {
+	client, _ := c.Locals("api_client").(*apikey.Client)
+	return client
+}
+
+// RequireAPIScope only lets API clients through that were granted every scope, used after APIKeyAuth
+// to limit an API key to the route groups it is meant for.
+func RequireAPIScope(scopes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		client := GetAPIClientFromContext(c)
+		if client == nil {
+			return c.Status(fiber.StatusForbidden).JSON(response.Error("Forbidden: api client not found"))
+		}
+
+		for _, scope := range scopes {
+			if !client.HasScope(scope) {
+				log.Warn().
+					Str("api_client_id", client.ID).
+					Str("scope", scope).
+					Strs("granted", client.Scopes).
+					Msg("middleware::RequireAPIScope - Api key lacks scope")
+				return c.Status(fiber.StatusForbidden).JSON(response.Error("Forbidden: api key lacks scope " + scope))
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// APIClientRateLimiter limits the requests per minute of every API client resolved by APIKeyAuth.
+func APIClientRateLimiter(perMinute int) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        perMinute,
+		Expiration: time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			if client := GetAPIClientFromContext(c); client != nil {
+				return "client:" + client.ID
+			}
+			return "ip:" + c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			log.Warn().Str("ip", c.IP()).Msg("middleware::APIClientRateLimiter - Rate limit exceeded")
+			return c.Status(fiber.StatusTooManyRequests).JSON(response.Error("Too many requests"))
+		},
+	})
+}
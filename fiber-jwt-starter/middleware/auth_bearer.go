@@ -1,29 +1,29 @@
 package middleware
 
 import (
+	"fiber-jwt-starter/config"
+	"fiber-jwt-starter/pkg/authcookie"
 	"fiber-jwt-starter/pkg/jwthandler"
+	"fiber-jwt-starter/pkg/revocation"
 	"github.com/gofiber/fiber/v2"
 	"strings"
 
 	"github.com/rs/zerolog/log"
 )
 
-// AuthBearer adalah middleware untuk validasi JWT Bearer token
+// AuthBearer adalah middleware untuk validasi JWT Bearer token, di cookie mode access token juga dibaca dari cookie
 func AuthBearer(c *fiber.Ctx) error {
-	accessToken := c.Get("Authorization")
 	unauthorizedResponse := fiber.Map{
 		"message": "Unauthorized",
 		"success": false,
 	}
 
-	if accessToken == "" || !strings.HasPrefix(accessToken, "Bearer ") {
+	tokenString := bearerToken(c)
+	if tokenString == "" {
 		log.Error().Msg("middleware::AuthBearer - Unauthorized [Missing or invalid Authorization header]")
 		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
 	}
 
-	// remove the "Bearer " prefix
-	tokenString := strings.TrimPrefix(accessToken, "Bearer ")
-
 	claims, err := jwthandler.ParseToken(tokenString)
 	if err != nil {
 		log.Error().
@@ -33,18 +33,74 @@ func AuthBearer(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
 	}
 
+	// only access and client tokens are bearer credentials, refresh tokens outlive the session
+	// revocation and mfa_pending tokens are only accepted by the second login step
+	if !bearerSubject(claims.Subject) {
+		log.Warn().
+			Str("user_id", claims.ID).
+			Str("subject", claims.Subject).
+			Msg("middleware::AuthBearer - Token subject not accepted as bearer")
+		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
+	}
+
+	// the token itself (logout) or its whole session (remote sign-out) may be revoked
+	revoked, err := revocation.IsRevoked(c.Context(), claims.JTI())
+	if err == nil && !revoked && claims.FamilyID != "" {
+		revoked, err = revocation.IsRevoked(c.Context(), revocation.FamilyKey(claims.FamilyID))
+	}
+	if err != nil || revoked {
+		log.Warn().
+			Err(err).
+			Str("user_id", claims.ID).
+			Str("jti", claims.JTI()).
+			Msg("middleware::AuthBearer - Token has been revoked")
+		return c.Status(fiber.StatusUnauthorized).JSON(unauthorizedResponse)
+	}
+
 	c.Locals("user_id", claims.ID)
 	c.Locals("role", claims.Role)
+	c.Locals("client_id", claims.ClientID) // client tokens carry no user_id and role
+	c.Locals("claims", claims)
 
 	return c.Next()
 }
 
+// bearerSubject reports whether a token of the subject may authenticate a request.
+func bearerSubject(subject string) bool {
+	switch jwthandler.TokenType(subject) {
+	case jwthandler.AccessToken, jwthandler.ClientToken:
+		return true
+	}
+	return false
+}
+
+// bearerToken returns the token of the Authorization header, falling back to the access cookie in cookie mode.
+func bearerToken(c *fiber.Ctx) string {
+	if authHeader := c.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	if authcookie.Enabled() {
+		return c.Cookies(config.Envs.Cookie.AccessName)
+	}
+	return ""
+}
+
 func GetUserIDFromContext(c *fiber.Ctx) string {
 	id, _ := c.Locals("user_id").(string)
 	return id
 }
 
+func GetClientIDFromContext(c *fiber.Ctx) string {
+	id, _ := c.Locals("client_id").(string)
+	return id
+}
+
 func GetRoleFromContext(c *fiber.Ctx) string {
 	role, _ := c.Locals("role").(string)
 	return role
 }
+
+func GetClaimsFromContext(c *fiber.Ctx) *jwthandler.CustomClaims {
+	claims, _ := c.Locals("claims").(*jwthandler.CustomClaims)
+	return claims
+}
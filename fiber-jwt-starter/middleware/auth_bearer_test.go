@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"fiber-jwt-starter/pkg/jwthandler"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerSubject(t *testing.T) {
+	cases := map[string]bool{
+		string(jwthandler.AccessToken):     true,
+		string(jwthandler.ClientToken):     true,
+		string(jwthandler.RefreshToken):    false,
+		string(jwthandler.MfaPendingToken): false,
+		"email_verification":               false,
+		"":                                 false,
+	}
+	for subject, want := range cases {
+		assert.Equal(t, want, bearerSubject(subject), subject)
+	}
+}
@@ -1,8 +1,12 @@
 package middleware
 
 import (
+	"fiber-jwt-starter/pkg/authz"
+	"fiber-jwt-starter/pkg/rbac"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
+	"slices"
+	"strings"
 )
 
 func AuthRole(authorizedRoles []string) func(*fiber.Ctx) error {
@@ -22,6 +26,15 @@ func AuthRole(authorizedRoles []string) func(*fiber.Ctx) error {
 		}
 
 		if _, exists := roleSet[role]; exists {
+			if MfaRequired(role) {
+				if claims := GetClaimsFromContext(c); claims == nil || !claims.MFA {
+					log.Warn().Str("role", role).Msg("middleware::AuthRole - MFA required for role")
+					return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+						"message": "Terlarang: role anda wajib login menggunakan MFA",
+						"success": false,
+					})
+				}
+			}
 			return c.Next()
 		}
 
@@ -36,3 +49,113 @@ func AuthRole(authorizedRoles []string) func(*fiber.Ctx) error {
 		})
 	}
 }
+
+// RequirePermission allows the request when the roles granted to the user (user_roles) carry every
+// given permission. Grants are resolved at request time through rbac.UserGrants, so changes apply
+// without re-login. Must run after AuthBearer.
+func RequirePermission(permissions ...string) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		userId := GetUserIDFromContext(c)
+		if userId == "" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"message": "Terlarang: user tidak ditemukan dalam context",
+				"success": false,
+			})
+		}
+
+		grants, err := rbac.UserGrants(c.Context(), userId)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", userId).Msg("middleware::RequirePermission - Failed to resolve permissions")
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"message": "Gagal memuat hak akses",
+				"success": false,
+			})
+		}
+
+		for _, role := range grants.Roles {
+			if MfaRequired(role) {
+				if claims := GetClaimsFromContext(c); claims == nil || !claims.MFA {
+					log.Warn().Str("user_id", userId).Str("role", role).Msg("middleware::RequirePermission - MFA required for role")
+					return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+						"message": "Terlarang: role anda wajib login menggunakan MFA",
+						"success": false,
+					})
+				}
+			}
+		}
+
+		for _, permission := range permissions {
+			if !grants.Has(permission) {
+				log.Warn().
+					Str("user_id", userId).
+					Str("permission", permission).
+					Strs("roles", grants.Roles).
+					Msg("middleware::RequirePermission - Forbidden")
+
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"message": "Terlarang: anda tidak memiliki izin " + permission,
+					"success": false,
+				})
+			}
+		}
+
+		c.Locals("permissions", grants.Permissions)
+		return c.Next()
+	}
+}
+
+// RequireScopes allows the request when the bearer token grants every given scope ("*" grants all).
+// User tokens issued without a scope are unrestricted, down-scoped user tokens and client tokens must
+// carry the scopes. Must run after AuthBearer.
+func RequireScopes(scopes ...string) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		claims := GetClaimsFromContext(c)
+		if claims == nil {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"message": "Terlarang: token tidak ditemukan dalam context",
+				"success": false,
+			})
+		}
+
+		for _, scope := range scopes {
+			if !claims.HasScope(scope) {
+				log.Warn().Any("payload", map[string]any{
+					"user_id":   claims.ID,
+					"client_id": claims.ClientID,
+					"scope":     scope,
+					"granted":   claims.Scope,
+				}).Msg("middleware::RequireScopes - Insufficient scope")
+
+				c.Set(fiber.HeaderWWWAuthenticate, `Bearer error="insufficient_scope", scope="`+strings.Join(scopes, " ")+`"`)
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"message": "Terlarang: token tidak memiliki scope " + scope,
+					"success": false,
+				})
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// GetSubjectFromContext builds the authz subject of the authenticated user from the JWT claims,
+// enriched with the roles and permissions granted in the database. Must run after AuthBearer.
+func GetSubjectFromContext(c *fiber.Ctx) authz.Subject {
+	sub := authz.SubjectFromClaims(GetClaimsFromContext(c))
+	if sub.ID == "" {
+		return sub
+	}
+
+	grants, err := rbac.UserGrants(c.Context(), sub.ID)
+	if err != nil {
+		log.Warn().Err(err).Str("user_id", sub.ID).Msg("middleware::GetSubjectFromContext - Failed to resolve grants, using JWT role only")
+		return sub
+	}
+	for _, role := range grants.Roles {
+		if !slices.Contains(sub.Roles, role) {
+			sub.Roles = append(sub.Roles, role)
+		}
+	}
+	sub.Permissions = grants.Permissions
+	return sub
+}
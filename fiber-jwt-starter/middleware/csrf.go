@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"fiber-jwt-starter/config"
+	"fiber-jwt-starter/pkg/authcookie"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// CSRF protects cookie authenticated requests with the double-submit pattern: unsafe methods must
+// send the value of the CSRF cookie in the CSRF header. Requests with a Bearer Authorization header
+// or without token cookies cannot be forged by another site and pass; any other Authorization scheme
+// is ignored by AuthBearer, which then falls back to the cookie, so it gets no exemption. No-op unless AUTH_COOKIE_ENABLED.
+func CSRF(c *fiber.Ctx) error {
+	cfg := config.Envs.Cookie
+	if !authcookie.Enabled() || !authcookie.UnsafeMethod(c.Method()) || strings.HasPrefix(c.Get("Authorization"), "Bearer ") ||
+		(c.Cookies(cfg.AccessName) == "" && c.Cookies(cfg.RefreshName) == "") {
+		return c.Next()
+	}
+
+	if !authcookie.ValidCSRF(c.Cookies(cfg.CsrfName), c.Get(cfg.CsrfHeader)) {
+		log.Warn().
+			Str("method", c.Method()).
+			Str("path", c.Path()).
+			Str("ip", c.IP()).
+			Msg("middleware::CSRF - Missing or invalid CSRF token")
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"message": "Terlarang: token CSRF tidak valid",
+			"success": false,
+		})
+	}
+
+	return c.Next()
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"fiber-jwt-starter/config"
+	"strings"
+)
+
+// MfaRequired is the policy hook used by AuthRole to decide whether a role must have completed
+// MFA. By default it checks AUTH_MFA_REQUIRED_ROLES, replace it to plug in a custom policy.
+var MfaRequired = func(role string) bool {
+	for _, r := range strings.Split(config.Envs.Auth.MfaRequiredRoles, ",") {
+		if strings.TrimSpace(r) == role {
+			return true
+		}
+	}
+	return false
+}
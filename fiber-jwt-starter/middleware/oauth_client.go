@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"encoding/base64"
+	"fiber-jwt-starter/pkg/apikey"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// OAuthClientAuth authenticates the calling client with its credentials (RFC 6749 section 2.3.1), sent as
+// HTTP Basic client_id:client_secret or as client_id/client_secret form fields. When scope is not empty the
+// client must be granted it. The client is stored like APIKeyAuth does.
+func OAuthClientAuth(authenticator apikey.Authenticator, scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		clientID, clientSecret, ok := basicAuth(c.Get(fiber.HeaderAuthorization))
+		if !ok {
+			clientID, clientSecret = c.FormValue("client_id"), c.FormValue("client_secret")
+		}
+		if clientID == "" || clientSecret == "" {
+			log.Warn().Str("ip", c.IP()).Msg("middleware::OAuthClientAuth - Missing client credentials")
+			return invalidClient(c)
+		}
+
+		client, err := authenticator.Authenticate(c.Context(), clientID, clientSecret)
+		if err != nil {
+			log.Warn().Err(err).Str("client_id", clientID).Str("ip", c.IP()).Msg("middleware::OAuthClientAuth - Invalid client credentials")
+			return invalidClient(c)
+		}
+
+		if scope != "" && !client.HasScope(scope) {
+			log.Warn().Str("client_id", clientID).Str("scope", scope).Msg("middleware::OAuthClientAuth - Client lacks scope")
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":             "insufficient_scope",
+				"error_description": "client is not allowed to use this endpoint",
+			})
+		}
+
+		c.Locals("api_client", client)
+		return c.Next()
+	}
+}
+
+// basicAuth parses an HTTP Basic Authorization header.
+func basicAuth(header string) (username, password string, ok bool) {
+	encoded, found := strings.CutPrefix(header, "Basic ")
+	if !found {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+func invalidClient(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="oauth"`)
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"error":             "invalid_client",
+		"error_description": "client authentication failed",
+	})
+}
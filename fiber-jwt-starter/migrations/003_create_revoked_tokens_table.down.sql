@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.revoked_tokens;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.mfa_recovery_codes;
+DROP TABLE IF EXISTS public.user_mfa;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE public.users DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE public.users DROP COLUMN IF EXISTS failed_login_count;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.oidc_states;
+DROP TABLE IF EXISTS public.user_identities;
\ No newline at end of file
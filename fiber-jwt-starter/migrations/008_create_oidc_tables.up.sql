@@ -0,0 +1,28 @@
+-- akun eksternal (OIDC) yang ditautkan ke user
+CREATE TABLE IF NOT EXISTS public.user_identities (
+    id UUID DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    email TEXT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    last_login_at TIMESTAMP NULL,
+    CONSTRAINT user_identities_pkey PRIMARY KEY (id),
+    CONSTRAINT user_identities_provider_subject_key UNIQUE (provider, subject),
+    CONSTRAINT user_identities_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS user_identities_user_id_idx ON public.user_identities (user_id);
+
+-- state, nonce dan PKCE verifier selama authorization code flow berlangsung
+CREATE TABLE IF NOT EXISTS public.oidc_states (
+    state_hash TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT oidc_states_pkey PRIMARY KEY (state_hash)
+);
+
+CREATE INDEX IF NOT EXISTS oidc_states_expires_at_idx ON public.oidc_states (expires_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS public.sessions (
+    id UUID DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    user_agent TEXT NULL,
+    ip TEXT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    last_seen_at TIMESTAMP DEFAULT now(),
+    revoked_at TIMESTAMP NULL,
+    CONSTRAINT sessions_pkey PRIMARY KEY (id),
+    CONSTRAINT sessions_family_id_key UNIQUE (family_id),
+    CONSTRAINT sessions_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON public.sessions (user_id);
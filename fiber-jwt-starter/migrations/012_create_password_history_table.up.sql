@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS public.password_history (
+    id UUID DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT password_history_pkey PRIMARY KEY (id),
+    CONSTRAINT password_history_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS password_history_user_id_created_at_idx ON public.password_history (user_id, created_at DESC);
+
+-- Current passwords count as the most recent history entry
+INSERT INTO public.password_history (user_id, password_hash, created_at)
+SELECT u.id, u.password, COALESCE(u.updated_at, u.created_at, now())
+FROM public.users u
+WHERE u.deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS public.users_email_active_key;
+ALTER TABLE public.users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE public.users DROP COLUMN IF EXISTS avatar_url;
+ALTER TABLE public.users DROP COLUMN IF EXISTS name;
\ No newline at end of file
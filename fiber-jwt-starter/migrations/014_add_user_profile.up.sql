@@ -0,0 +1,6 @@
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS name TEXT NULL;
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS avatar_url TEXT NULL;
+
+-- soft deleted accounts keep their row, their email can be registered again
+ALTER TABLE public.users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_active_key ON public.users (email) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE public.users DROP COLUMN IF EXISTS disabled_at;
\ No newline at end of file
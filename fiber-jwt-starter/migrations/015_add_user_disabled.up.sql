@@ -0,0 +1,2 @@
+-- disabled accounts keep their data but cannot sign in until enabled again
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP NULL;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS public.password_resets (
+    id UUID DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    token_hash TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT now(),
+    CONSTRAINT password_resets_pkey PRIMARY KEY (id),
+    CONSTRAINT password_resets_token_hash_key UNIQUE (token_hash),
+    CONSTRAINT password_resets_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS password_resets_user_id_idx ON public.password_resets (user_id);
+
+INSERT INTO public.password_resets (id, user_id, token_hash, expires_at, used_at, created_at)
+SELECT id, user_id, token_hash, expires_at, used_at, created_at
+FROM public.user_tokens
+WHERE purpose = 'password_reset'
+ON CONFLICT DO NOTHING;
+
+DELETE FROM public.user_tokens WHERE purpose = 'password_reset';
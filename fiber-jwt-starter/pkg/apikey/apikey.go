@@ -0,0 +1,85 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	keyPrefix    = "sk_"
+	prefixLength = 10
+)
+
+// Client is the calling API client resolved from the x-api-key header or from OAuth client credentials.
+type Client struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope reports whether the client was granted the scope, "*" grants every scope.
+func (c *Client) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == "*" || s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolver resolves a raw API key to its client, used by the API key middleware.
+type Resolver interface {
+	Resolve(ctx context.Context, key string) (*Client, error)
+}
+
+// Authenticator authenticates a client by its client_id and client_secret, used by the OAuth endpoints.
+type Authenticator interface {
+	Authenticate(ctx context.Context, clientID, clientSecret string) (*Client, error)
+}
+
+// Generate returns a new key of the form sk_<prefix>_<secret> together with its lookup prefix and hash.
+// Only the prefix and hash are stored, the key itself is shown once to the caller.
+func Generate() (key, prefix, hash string, err error) {
+	p := make([]byte, prefixLength/2)
+	if _, err = rand.Read(p); err != nil {
+		return "", "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = hex.EncodeToString(p)
+	key = keyPrefix + prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return key, prefix, Hash(key), nil
+}
+
+// Prefix extracts the lookup prefix of a key, ok is false when the key is malformed.
+func Prefix(key string) (string, bool) {
+	if !strings.HasPrefix(key, keyPrefix) || len(key) <= len(keyPrefix)+prefixLength+1 || key[len(keyPrefix)+prefixLength] != '_' {
+		return "", false
+	}
+	return key[len(keyPrefix) : len(keyPrefix)+prefixLength], true
+}
+
+// Hash returns the hex encoded SHA-256 digest stored for a key.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Matches compares a key against a stored hash in constant time.
+func Matches(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
+
+// Equal compares two secrets in constant time, used for the legacy single X_API_KEY.
+func Equal(a, b string) bool {
+	x, y := sha256.Sum256([]byte(a)), sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(x[:], y[:]) == 1
+}
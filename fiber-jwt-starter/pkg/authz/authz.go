@@ -0,0 +1,102 @@
+package authz
+
+import (
+	"context"
+	"fiber-jwt-starter/pkg/errmsg"
+	"fiber-jwt-starter/pkg/jwthandler"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Subject is the caller being authorized, built from the AuthBearer claims and optionally
+// enriched with database roles/permissions or custom attributes such as tenant_id.
+type Subject struct {
+	ID          string
+	Roles       []string
+	Permissions []string
+	Attrs       map[string]string
+}
+
+// Resource is the object an action is performed on. OwnerID enables ownership rules.
+type Resource struct {
+	Type    string
+	ID      string
+	OwnerID string
+	Attrs   map[string]string
+}
+
+// Decision is the outcome of a policy evaluation, Rule names the rule that decided.
+type Decision struct {
+	Allowed bool
+	Rule    string
+	Reason  string
+}
+
+// Policy decides whether the subject may perform the action on the resource.
+type Policy interface {
+	Evaluate(ctx context.Context, sub Subject, action string, res Resource) Decision
+}
+
+// SubjectFromClaims builds the subject of an authenticated request.
+func SubjectFromClaims(claims *jwthandler.CustomClaims) Subject {
+	if claims == nil {
+		return Subject{}
+	}
+	return Subject{
+		ID:    claims.ID,
+		Roles: []string{claims.Role},
+		Attrs: map[string]string{
+			"role": claims.Role,
+			"mfa":  strconv.FormatBool(claims.MFA),
+		},
+	}
+}
+
+var (
+	policy Policy = DefaultPolicy()
+	mu     sync.RWMutex
+)
+
+// SetPolicy replaces the policy used by Authorize and Can.
+func SetPolicy(p Policy) {
+	mu.Lock()
+	defer mu.Unlock()
+	policy = p
+}
+
+// Evaluate evaluates the active policy.
+func Evaluate(ctx context.Context, sub Subject, action string, res Resource) Decision {
+	mu.RLock()
+	p := policy
+	mu.RUnlock()
+	return p.Evaluate(ctx, sub, action, res)
+}
+
+// Can reports whether the active policy allows the action.
+func Can(ctx context.Context, sub Subject, action string, res Resource) bool {
+	return Evaluate(ctx, sub, action, res).Allowed
+}
+
+// Authorize evaluates the active policy and returns a 403 error when the action is denied.
+// Denied decisions are logged with the subject, action, resource and deciding rule.
+func Authorize(ctx context.Context, sub Subject, action string, res Resource) error {
+	decision := Evaluate(ctx, sub, action, res)
+	if decision.Allowed {
+		return nil
+	}
+
+	log.Warn().
+		Str("subject", sub.ID).
+		Strs("roles", sub.Roles).
+		Str("action", action).
+		Str("resource_type", res.Type).
+		Str("resource_id", res.ID).
+		Str("rule", decision.Rule).
+		Str("reason", decision.Reason).
+		Msg("authz::Authorize - Access denied")
+
+	return errmsg.NewCustomErrors(http.StatusForbidden, errmsg.WithMessage("Terlarang: anda tidak diizinkan melakukan aksi ini"))
+}
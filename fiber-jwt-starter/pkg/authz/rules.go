@@ -0,0 +1,252 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// Rule matches a request when any of its roles (empty: every subject), any of its actions and any of
+// its resource types match, and all conditions hold. Actions and resource types are glob patterns,
+// e.g. "users:*", "*:read" or "*".
+//
+// Conditions compare two operands with == or !=. An operand is an attribute (subject.id,
+// subject.<attr>, resource.id, resource.type, resource.owner_id, resource.<attr>) or a quoted
+// literal. A condition referencing a missing or empty attribute never holds.
+type Rule struct {
+	Name       string   `json:"name"`
+	Effect     string   `json:"effect"`
+	Roles      []string `json:"roles,omitempty"`
+	Actions    []string `json:"actions"`
+	Resources  []string `json:"resources"`
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// Document is the file format read by Load.
+type Document struct {
+	Default string `json:"default"` // effect when no rule matches, deny when empty
+	Rules   []Rule `json:"rules"`
+}
+
+type operand struct {
+	attr    string // subject.x or resource.x
+	literal string
+}
+
+type condition struct {
+	left, right operand
+	negate      bool
+}
+
+type compiledRule struct {
+	Rule
+	conditions []condition
+}
+
+// Engine evaluates declarative rules, a matching deny rule always wins over allow rules.
+type Engine struct {
+	defaultAllow bool
+	rules        []compiledRule
+}
+
+// NewEngine compiles the document into an Engine.
+func NewEngine(doc Document) (*Engine, error) {
+	e := &Engine{}
+	switch doc.Default {
+	case "", EffectDeny:
+	case EffectAllow:
+		e.defaultAllow = true
+	default:
+		return nil, fmt.Errorf("authz: unknown default effect %q", doc.Default)
+	}
+
+	for i, rule := range doc.Rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+			return nil, fmt.Errorf("authz: rule %q has unknown effect %q", rule.Name, rule.Effect)
+		}
+		if len(rule.Actions) == 0 || len(rule.Resources) == 0 {
+			return nil, fmt.Errorf("authz: rule %q needs actions and resources", rule.Name)
+		}
+		for _, pattern := range append(append([]string{}, rule.Actions...), rule.Resources...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("authz: rule %q has invalid pattern %q", rule.Name, pattern)
+			}
+		}
+
+		compiled := compiledRule{Rule: rule}
+		for _, expr := range rule.Conditions {
+			cond, err := parseCondition(expr)
+			if err != nil {
+				return nil, fmt.Errorf("authz: rule %q: %w", rule.Name, err)
+			}
+			compiled.conditions = append(compiled.conditions, cond)
+		}
+		e.rules = append(e.rules, compiled)
+	}
+	return e, nil
+}
+
+// Load reads a JSON Document from file. An empty path yields DefaultPolicy.
+func Load(file string) (*Engine, error) {
+	if file == "" {
+		return DefaultPolicy(), nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("authz: reading policy file: %w", err)
+	}
+	var doc Document
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("authz: parsing policy file: %w", err)
+	}
+	return NewEngine(doc)
+}
+
+// DefaultPolicy allows admins everything and every subject any action on resources they own.
+func DefaultPolicy() *Engine {
+	e, err := NewEngine(Document{
+		Default: EffectDeny,
+		Rules: []Rule{
+			{Name: "admin", Effect: EffectAllow, Roles: []string{"admin"}, Actions: []string{"*"}, Resources: []string{"*"}},
+			{Name: "owner", Effect: EffectAllow, Actions: []string{"*"}, Resources: []string{"*"}, Conditions: []string{"subject.id == resource.owner_id"}},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Evaluate implements Policy.
+func (e *Engine) Evaluate(ctx context.Context, sub Subject, action string, res Resource) Decision {
+	var allowedBy string
+	for _, rule := range e.rules {
+		if !rule.matches(sub, action, res) {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return Decision{Allowed: false, Rule: rule.Name, Reason: "denied by rule"}
+		}
+		if allowedBy == "" {
+			allowedBy = rule.Name
+		}
+	}
+
+	if allowedBy != "" {
+		return Decision{Allowed: true, Rule: allowedBy, Reason: "allowed by rule"}
+	}
+	if e.defaultAllow {
+		return Decision{Allowed: true, Reason: "no matching rule, default allow"}
+	}
+	return Decision{Allowed: false, Reason: "no matching rule"}
+}
+
+func (r compiledRule) matches(sub Subject, action string, res Resource) bool {
+	if len(r.Roles) > 0 && !anyRole(r.Roles, sub.Roles) {
+		return false
+	}
+	if !anyMatch(r.Actions, action) || !anyMatch(r.Resources, res.Type) {
+		return false
+	}
+	for _, cond := range r.conditions {
+		if !cond.holds(sub, res) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyRole(want, have []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if w == h {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func anyMatch(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCondition(expr string) (condition, error) {
+	op, negate := "==", false
+	if strings.Contains(expr, "!=") {
+		op, negate = "!=", true
+	}
+	left, right, ok := strings.Cut(expr, op)
+	if !ok {
+		return condition{}, fmt.Errorf("condition %q needs == or !=", expr)
+	}
+
+	l, err := parseOperand(left)
+	if err != nil {
+		return condition{}, fmt.Errorf("condition %q: %w", expr, err)
+	}
+	r, err := parseOperand(right)
+	if err != nil {
+		return condition{}, fmt.Errorf("condition %q: %w", expr, err)
+	}
+	return condition{left: l, right: r, negate: negate}, nil
+}
+
+func parseOperand(s string) (operand, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return operand{literal: s[1 : len(s)-1]}, nil
+	}
+	if strings.HasPrefix(s, "subject.") || strings.HasPrefix(s, "resource.") {
+		return operand{attr: s}, nil
+	}
+	return operand{}, fmt.Errorf("unknown operand %q", s)
+}
+
+func (o operand) value(sub Subject, res Resource) string {
+	if o.attr == "" {
+		return o.literal
+	}
+
+	scope, name, _ := strings.Cut(o.attr, ".")
+	if scope == "subject" {
+		if name == "id" {
+			return sub.ID
+		}
+		return sub.Attrs[name]
+	}
+	switch name {
+	case "id":
+		return res.ID
+	case "type":
+		return res.Type
+	case "owner_id":
+		return res.OwnerID
+	}
+	return res.Attrs[name]
+}
+
+func (c condition) holds(sub Subject, res Resource) bool {
+	l, r := c.left.value(sub, res), c.right.value(sub, res)
+	if (c.left.attr != "" && l == "") || (c.right.attr != "" && r == "") {
+		return false
+	}
+	return (l == r) != c.negate
+}
@@ -0,0 +1,56 @@
+package clientip
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ParseTrustedProxies parses APP_TRUSTED_PROXIES, a comma separated list of CIDRs or single IPs of the
+// reverse proxies in front of the server.
+func ParseTrustedProxies(raw string) ([]*net.IPNet, error) {
+	var ranges []*net.IPNet
+	for _, entry := range strings.Split(raw, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, &net.ParseError{Type: "IP address", Text: entry}
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			ranges = append(ranges, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipRange, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, ipRange)
+	}
+	return ranges, nil
+}
+
+// Apply configures how c.IP() finds the client IP: the peer address, or proxyHeader when the request
+// comes from a trusted proxy. Fiber reads the first address of the header, so the proxy must overwrite
+// it with its own peer address (e.g. nginx proxy_set_header X-Real-IP $remote_addr).
+func Apply(cfg *fiber.Config, trustedProxies, proxyHeader string) error {
+	ranges, err := ParseTrustedProxies(trustedProxies)
+	if err != nil || len(ranges) == 0 {
+		return err
+	}
+
+	cfg.ProxyHeader = proxyHeader
+	cfg.EnableTrustedProxyCheck = true
+	cfg.EnableIPValidation = true
+	cfg.TrustedProxies = make([]string, 0, len(ranges))
+	for _, ipRange := range ranges {
+		cfg.TrustedProxies = append(cfg.TrustedProxies, ipRange.String())
+	}
+	return nil
+}
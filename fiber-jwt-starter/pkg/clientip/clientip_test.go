@@ -0,0 +1,56 @@
+package clientip
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clientIP returns c.IP() of a test request, app.Test connects from 0.0.0.0.
+func clientIP(t *testing.T, trustedProxies string) string {
+	t.Helper()
+	cfg := fiber.Config{}
+	require.NoError(t, Apply(&cfg, trustedProxies, "X-Real-IP"))
+
+	app := fiber.New(cfg)
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(c.IP())
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Real-IP", "203.0.113.7")
+	req.Header.Set(fiber.HeaderXForwardedFor, "198.51.100.1")
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestApplyIgnoresProxyHeaderByDefault(t *testing.T) {
+	assert.Equal(t, "0.0.0.0", clientIP(t, ""))
+}
+
+func TestApplyTrustsConfiguredProxies(t *testing.T) {
+	assert.Equal(t, "203.0.113.7", clientIP(t, "0.0.0.0"))
+	// the header of an untrusted peer is ignored
+	assert.Equal(t, "0.0.0.0", clientIP(t, "10.0.0.0/8"))
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	ranges, err := ParseTrustedProxies(" 10.0.0.0/8 ,192.0.2.10,2001:db8::1,")
+	require.NoError(t, err)
+	require.Len(t, ranges, 3)
+	assert.Equal(t, "10.0.0.0/8", ranges[0].String())
+	assert.Equal(t, "192.0.2.10/32", ranges[1].String())
+	assert.Equal(t, "2001:db8::1/128", ranges[2].String())
+
+	_, err = ParseTrustedProxies("10.0.0.0/33")
+	assert.Error(t, err)
+	_, err = ParseTrustedProxies("proxy.local")
+	assert.Error(t, err)
+}
@@ -1,5 +1,7 @@
 package errmsg
 
+import "errors"
+
 type CustomError struct {
 	Code   int
 	Errors map[string][]string
@@ -53,3 +55,9 @@ func WithErrors(field string, msg string) Option {
 func errorCustomHandler(err *CustomError) (int, *CustomError) {
 	return err.Code, err
 }
+
+// HasCode reports whether err is a CustomError with the given status code.
+func HasCode(err error, code int) bool {
+	var customErr *CustomError
+	return errors.As(err, &customErr) && customErr.Code == code
+}
@@ -0,0 +1,255 @@
+package jwthandler
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const manifestFileName = "keyring.json"
+
+// Manifest describes the keys stored in a key directory (JWT_KEY_DIR).
+type Manifest struct {
+	Current string        `json:"current"`
+	Keys    []ManifestKey `json:"keys"`
+}
+
+// ManifestKey is a key file entry. DemotedAt is set once another key was promoted,
+// the key then only verifies tokens until it is retired.
+type ManifestKey struct {
+	Kid       string     `json:"kid"`
+	Alg       string     `json:"alg"`
+	File      string     `json:"file"`
+	CreatedAt time.Time  `json:"created_at"`
+	DemotedAt *time.Time `json:"demoted_at,omitempty"`
+}
+
+func manifestPath(dir string) string {
+	return filepath.Join(dir, manifestFileName)
+}
+
+// ReadManifest reads the manifest of dir, an empty manifest is returned when none exists yet.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(manifestPath(dir))
+	if os.IsNotExist(err) {
+		return &Manifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwthandler: read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("jwthandler: parse manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// WriteManifest atomically replaces the manifest of dir.
+func WriteManifest(dir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := manifestPath(dir) + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("jwthandler: write manifest: %w", err)
+	}
+	return os.Rename(tmp, manifestPath(dir))
+}
+
+// LoadKeyDir builds a key ring from the manifest and key files in dir.
+func LoadKeyDir(dir string) (*KeyRing, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if m.Current == "" {
+		return nil, fmt.Errorf("jwthandler: no current key in %s, generate and promote one with cmd/keyring", dir)
+	}
+
+	var (
+		current  *Key
+		previous []*Key
+	)
+	for _, entry := range m.Keys {
+		key, err := loadManifestKey(dir, entry)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Kid == m.Current {
+			current = key
+		} else {
+			previous = append(previous, key)
+		}
+	}
+	if current == nil {
+		return nil, fmt.Errorf("jwthandler: current key %q not found in manifest", m.Current)
+	}
+
+	return NewKeyRing(current, previous...)
+}
+
+func loadManifestKey(dir string, entry ManifestKey) (*Key, error) {
+	path := filepath.Join(dir, entry.File)
+	if entry.Alg != "HS256" {
+		return LoadKey(entry.Alg, entry.Kid, "", path)
+	}
+
+	secret, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwthandler: read secret: %w", err)
+	}
+	return LoadKey(entry.Alg, entry.Kid, strings.TrimSpace(string(secret)), "")
+}
+
+// GenerateKeyFile creates a new key for alg in dir and adds it to the manifest.
+// The first key of an empty directory becomes the current key, later keys must be promoted.
+func GenerateKeyFile(dir, alg string, now time.Time) (ManifestKey, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return ManifestKey{}, err
+	}
+
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return ManifestKey{}, err
+	}
+
+	kid, data, ext, err := generateKeyMaterial(alg)
+	if err != nil {
+		return ManifestKey{}, err
+	}
+
+	entry := ManifestKey{Kid: kid, Alg: alg, File: kid + ext, CreatedAt: now.UTC()}
+	if err = os.WriteFile(filepath.Join(dir, entry.File), data, 0o600); err != nil {
+		return ManifestKey{}, fmt.Errorf("jwthandler: write key file: %w", err)
+	}
+
+	m.Keys = append(m.Keys, entry)
+	if m.Current == "" {
+		m.Current = kid
+	}
+	return entry, WriteManifest(dir, m)
+}
+
+func generateKeyMaterial(alg string) (kid string, data []byte, ext string, err error) {
+	var privateKey any
+	switch alg {
+	case "HS256":
+		secret := make([]byte, 32)
+		if _, err = rand.Read(secret); err != nil {
+			return "", nil, "", err
+		}
+		id := make([]byte, 8)
+		if _, err = rand.Read(id); err != nil {
+			return "", nil, "", err
+		}
+		return hex.EncodeToString(id), []byte(base64.RawURLEncoding.EncodeToString(secret)), ".secret", nil
+	case "RS256":
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "EdDSA":
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return "", nil, "", fmt.Errorf("jwthandler: unsupported signing algorithm %q", alg)
+	}
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", nil, "", err
+	}
+	data = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	key, err := parsePrivateKey(alg, data)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if kid, err = keyThumbprint(key.verifyKey); err != nil {
+		return "", nil, "", err
+	}
+	return kid, data, ".pem", nil
+}
+
+// PromoteKey makes kid the signing key, the previous signing key keeps verifying until retired.
+func PromoteKey(dir, kid string, now time.Time) error {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+	if m.Current == kid {
+		return nil
+	}
+
+	found := false
+	for i := range m.Keys {
+		switch m.Keys[i].Kid {
+		case kid:
+			found = true
+			m.Keys[i].DemotedAt = nil
+		case m.Current:
+			demotedAt := now.UTC()
+			m.Keys[i].DemotedAt = &demotedAt
+		}
+	}
+	if !found {
+		return fmt.Errorf("jwthandler: key %q not found in manifest", kid)
+	}
+
+	m.Current = kid
+	return WriteManifest(dir, m)
+}
+
+// RetireKeys removes keys demoted at least gracePeriod ago (use the refresh token TTL, the longest
+// lived token signed by them) and returns their kid.
+func RetireKeys(dir string, gracePeriod time.Duration, now time.Time) ([]string, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		kept    []ManifestKey
+		retired []ManifestKey
+	)
+	for _, entry := range m.Keys {
+		if entry.Kid == m.Current || entry.DemotedAt == nil || now.Sub(*entry.DemotedAt) < gracePeriod {
+			kept = append(kept, entry)
+			continue
+		}
+		retired = append(retired, entry)
+	}
+	if len(retired) == 0 {
+		return nil, nil
+	}
+
+	m.Keys = kept
+	if err = WriteManifest(dir, m); err != nil {
+		return nil, err
+	}
+
+	kids := make([]string, 0, len(retired))
+	for _, entry := range retired {
+		if err = os.Remove(filepath.Join(dir, entry.File)); err != nil && !os.IsNotExist(err) {
+			return kids, fmt.Errorf("jwthandler: remove key file: %w", err)
+		}
+		kids = append(kids, entry.Kid)
+	}
+	return kids, nil
+}
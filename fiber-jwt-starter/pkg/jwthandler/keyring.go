@@ -0,0 +1,198 @@
+package jwthandler
+
+import (
+	"fiber-jwt-starter/config"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// KeyRing holds the current signing key plus previous keys that are still accepted for verification,
+// so rotating the signing key does not invalidate tokens issued before the rotation.
+type KeyRing struct {
+	current *Key
+	keys    map[string]*Key
+}
+
+// NewKeyRing creates a key ring signing with current and verifying with current plus previous.
+func NewKeyRing(current *Key, previous ...*Key) (*KeyRing, error) {
+	if current == nil || !current.CanSign() {
+		return nil, fmt.Errorf("jwthandler: current key must be able to sign")
+	}
+
+	ring := &KeyRing{current: current, keys: map[string]*Key{current.ID: current}}
+	for _, key := range previous {
+		if _, exists := ring.keys[key.ID]; exists {
+			return nil, fmt.Errorf("jwthandler: duplicate kid %q", key.ID)
+		}
+		ring.keys[key.ID] = key
+	}
+	return ring, nil
+}
+
+// Current returns the signing key.
+func (r *KeyRing) Current() *Key {
+	return r.current
+}
+
+// Lookup returns the verification key for kid.
+func (r *KeyRing) Lookup(kid string) (*Key, bool) {
+	key, ok := r.keys[kid]
+	return key, ok
+}
+
+// Keys returns every verification key, current key first.
+func (r *KeyRing) Keys() []*Key {
+	keys := []*Key{r.current}
+	ids := make([]string, 0, len(r.keys))
+	for id := range r.keys {
+		if id != r.current.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		keys = append(keys, r.keys[id])
+	}
+	return keys
+}
+
+// algorithms returns the distinct algorithms in the ring, used to restrict accepted alg headers.
+func (r *KeyRing) algorithms() []string {
+	seen := make(map[string]struct{})
+	var algs []string
+	for _, key := range r.keys {
+		if _, ok := seen[key.Method.Alg()]; !ok {
+			seen[key.Method.Alg()] = struct{}{}
+			algs = append(algs, key.Method.Alg())
+		}
+	}
+	return algs
+}
+
+var (
+	keyRing *KeyRing
+	keyMu   sync.RWMutex
+)
+
+// Setup loads the key ring from config.Envs.Guard, call it at startup to fail fast on a bad key.
+// When JWT_KEY_DIR is set the keys come from its manifest, otherwise from JWT_ALGORITHM/JWT_SECRET/
+// JWT_PRIVATE_KEY_FILE plus the retired keys listed in JWT_PREVIOUS_KEYS.
+func Setup() error {
+	ring, err := loadKeyRingFromConfig(config.Envs)
+	if err != nil {
+		return err
+	}
+	setKeyRing(ring)
+	return nil
+}
+
+func loadKeyRingFromConfig(cfg *config.Config) (*KeyRing, error) {
+	guard := cfg.Guard
+	if guard.JwtKeyDir != "" {
+		return LoadKeyDir(guard.JwtKeyDir)
+	}
+
+	current, err := LoadKey(guard.JwtAlgorithm, guard.JwtKeyID, guard.JwtSecret, guard.JwtPrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := parsePreviousKeys(guard.JwtPreviousKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeyRing(current, previous...)
+}
+
+// parsePreviousKeys parses "kid:ALG:secret-or-pem-path" entries separated by commas.
+func parsePreviousKeys(value string) ([]*Key, error) {
+	var keys []*Key
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("jwthandler: invalid JWT_PREVIOUS_KEYS entry %q, expected kid:ALG:secret-or-path", entry)
+		}
+
+		kid, alg, material := parts[0], parts[1], parts[2]
+		var (
+			key *Key
+			err error
+		)
+		if alg == "HS256" {
+			key, err = LoadKey(alg, kid, material, "")
+		} else {
+			key, err = LoadKey(alg, kid, "", material)
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func setKeyRing(ring *KeyRing) {
+	keyMu.Lock()
+	defer keyMu.Unlock()
+	keyRing = ring
+}
+
+func getKeyRing() (*KeyRing, error) {
+	keyMu.RLock()
+	ring := keyRing
+	keyMu.RUnlock()
+	if ring != nil {
+		return ring, nil
+	}
+
+	if err := Setup(); err != nil {
+		return nil, err
+	}
+	return getKeyRing()
+}
+
+// WatchKeyDir reloads the key ring whenever the manifest in JWT_KEY_DIR changes,
+// so keys promoted with cmd/keyring are picked up without restarting the server.
+func WatchKeyDir(interval time.Duration) {
+	dir := config.Envs.Guard.JwtKeyDir
+	if dir == "" {
+		return
+	}
+
+	go func() {
+		var lastModified time.Time
+		if info, err := os.Stat(manifestPath(dir)); err == nil {
+			lastModified = info.ModTime()
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			info, err := os.Stat(manifestPath(dir))
+			if err != nil || !info.ModTime().After(lastModified) {
+				continue
+			}
+			lastModified = info.ModTime()
+
+			ring, err := LoadKeyDir(dir)
+			if err != nil {
+				log.Error().Err(err).Msg("jwthandler::WatchKeyDir - failed to reload key ring, keeping previous keys")
+				continue
+			}
+			setKeyRing(ring)
+			log.Info().Str("kid", ring.Current().ID).Msg("jwthandler::WatchKeyDir - key ring reloaded")
+		}
+	}()
+}
@@ -0,0 +1,168 @@
+package jwthandler
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const defaultKeyID = "default"
+
+// Key is a JWT signing key together with the key used to verify it.
+type Key struct {
+	ID         string
+	Method     jwt.SigningMethod
+	signingKey any
+	verifyKey  any
+}
+
+// IsSymmetric reports whether the key is a shared HMAC secret that must never be published.
+func (k *Key) IsSymmetric() bool {
+	_, ok := k.Method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// PublicKey returns the public part of an asymmetric key, or nil for HMAC keys.
+func (k *Key) PublicKey() crypto.PublicKey {
+	if k.IsSymmetric() {
+		return nil
+	}
+	return k.verifyKey
+}
+
+// LoadKey builds a Key for the given algorithm (HS256, RS256, ES256 or EdDSA).
+// HS256 uses secret, the asymmetric algorithms read a PEM encoded private key from privateKeyFile.
+// When kid is empty it is derived from the public key.
+func LoadKey(alg, kid, secret, privateKeyFile string) (*Key, error) {
+	if alg == "" {
+		alg = jwt.SigningMethodHS256.Alg()
+	}
+
+	if alg == jwt.SigningMethodHS256.Alg() {
+		if secret == "" {
+			return nil, fmt.Errorf("jwthandler: JWT_SECRET is required for %s", alg)
+		}
+		if kid == "" {
+			kid = defaultKeyID
+		}
+		return &Key{ID: kid, Method: jwt.SigningMethodHS256, signingKey: []byte(secret), verifyKey: []byte(secret)}, nil
+	}
+
+	if privateKeyFile == "" {
+		return nil, fmt.Errorf("jwthandler: JWT_PRIVATE_KEY_FILE is required for %s", alg)
+	}
+	pemBytes, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("jwthandler: read private key: %w", err)
+	}
+
+	key, err := parsePrivateKey(alg, pemBytes)
+	if err != nil {
+		// previous keys only need to verify, so a public key is enough
+		var pubErr error
+		if key, pubErr = parsePublicKey(alg, pemBytes); pubErr != nil {
+			return nil, err
+		}
+	}
+
+	if kid == "" {
+		kid, err = keyThumbprint(key.verifyKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	key.ID = kid
+
+	return key, nil
+}
+
+func parsePrivateKey(alg string, pemBytes []byte) (*Key, error) {
+	switch alg {
+	case jwt.SigningMethodRS256.Alg():
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: parse RSA private key: %w", err)
+		}
+		return &Key{Method: jwt.SigningMethodRS256, signingKey: privateKey, verifyKey: &privateKey.PublicKey}, nil
+
+	case jwt.SigningMethodES256.Alg():
+		privateKey, err := jwt.ParseECPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: parse EC private key: %w", err)
+		}
+		if privateKey.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("jwthandler: ES256 requires a P-256 key")
+		}
+		return &Key{Method: jwt.SigningMethodES256, signingKey: privateKey, verifyKey: &privateKey.PublicKey}, nil
+
+	case jwt.SigningMethodEdDSA.Alg():
+		parsed, err := jwt.ParseEdPrivateKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: parse Ed25519 private key: %w", err)
+		}
+		privateKey, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwthandler: EdDSA requires an Ed25519 key")
+		}
+		return &Key{Method: jwt.SigningMethodEdDSA, signingKey: privateKey, verifyKey: privateKey.Public()}, nil
+	}
+
+	return nil, fmt.Errorf("jwthandler: unsupported signing algorithm %q", alg)
+}
+
+func parsePublicKey(alg string, pemBytes []byte) (*Key, error) {
+	switch alg {
+	case jwt.SigningMethodRS256.Alg():
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: parse RSA public key: %w", err)
+		}
+		return &Key{Method: jwt.SigningMethodRS256, verifyKey: publicKey}, nil
+
+	case jwt.SigningMethodES256.Alg():
+		publicKey, err := jwt.ParseECPublicKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: parse EC public key: %w", err)
+		}
+		return &Key{Method: jwt.SigningMethodES256, verifyKey: publicKey}, nil
+
+	case jwt.SigningMethodEdDSA.Alg():
+		publicKey, err := jwt.ParseEdPublicKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwthandler: parse Ed25519 public key: %w", err)
+		}
+		return &Key{Method: jwt.SigningMethodEdDSA, verifyKey: publicKey}, nil
+	}
+
+	return nil, fmt.Errorf("jwthandler: unsupported signing algorithm %q", alg)
+}
+
+// CanSign reports whether the key holds private material and can be promoted to signing key.
+func (k *Key) CanSign() bool {
+	return k.signingKey != nil
+}
+
+// keyThumbprint derives a stable key id from the DER encoded public key.
+func keyThumbprint(publicKey any) (string, error) {
+	switch publicKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return "", fmt.Errorf("jwthandler: unsupported public key type %T", publicKey)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("jwthandler: marshal public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
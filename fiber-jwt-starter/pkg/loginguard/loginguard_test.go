@@ -0,0 +1,63 @@
+package loginguard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 10*time.Minute
+	cases := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{4, 0},
+		{5, 30 * time.Second},
+		{6, time.Minute},
+		{7, 2 * time.Minute},
+		{9, 8 * time.Minute},
+		{10, 10 * time.Minute},
+		{50, 10 * time.Minute},
+	}
+
+	for _, tc := range cases {
+		if got := Backoff(tc.failures, 5, base, max); got != tc.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tc.failures, got, tc.want)
+		}
+	}
+}
+
+func TestGuardLocksAndResets(t *testing.T) {
+	g := New(2, time.Minute, time.Hour, 0)
+	now := time.Now()
+
+	if lock := g.Fail("ip:1.2.3.4", now); lock != 0 {
+		t.Fatalf("first failure should not lock, got %s", lock)
+	}
+	if lock := g.Fail("ip:1.2.3.4", now); lock != time.Minute {
+		t.Fatalf("second failure should lock for 1m, got %s", lock)
+	}
+	if remaining := g.LockedFor("ip:1.2.3.4", now.Add(30*time.Second)); remaining != 30*time.Second {
+		t.Fatalf("expected 30s remaining, got %s", remaining)
+	}
+	if remaining := g.LockedFor("ip:1.2.3.4", now.Add(time.Minute)); remaining != 0 {
+		t.Fatalf("lock should be expired, got %s", remaining)
+	}
+
+	g.Reset("ip:1.2.3.4")
+	if lock := g.Fail("ip:1.2.3.4", now); lock != 0 {
+		t.Fatalf("failures should start over after reset, got %s", lock)
+	}
+}
+
+func TestGuardEvictIdle(t *testing.T) {
+	g := New(5, time.Second, time.Minute, 0)
+	now := time.Now()
+	g.Fail("email:a@example.com", now)
+
+	g.evictIdle(now.Add(2 * time.Minute))
+	if _, found := g.entries["email:a@example.com"]; found {
+		t.Fatal("idle entry should be evicted")
+	}
+}
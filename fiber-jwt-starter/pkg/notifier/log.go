@@ -0,0 +1,23 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LogNotifier writes messages to the application log, meant for local development only.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Send(_ context.Context, msg Message) error {
+	log.Info().
+		Str("to", msg.To).
+		Str("subject", msg.Subject).
+		Str("body", msg.Body).
+		Msg("notifier::LogNotifier - message sent")
+	return nil
+}
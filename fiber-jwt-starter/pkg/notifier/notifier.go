@@ -0,0 +1,28 @@
+package notifier
+
+import (
+	"context"
+	"fiber-jwt-starter/config"
+)
+
+// Message is a notification addressed to a single recipient (usually an email address).
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages such as password reset links to users.
+// Plug a real provider (SMTP, SES, ...) by implementing this interface.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewFromConfig returns the notifier selected by NOTIFIER_DRIVER (log | file).
+func NewFromConfig() Notifier {
+	cfg := config.Envs.Notifier
+	if cfg.Driver == "file" {
+		return NewFileNotifier(cfg.FileDir)
+	}
+	return NewLogNotifier()
+}
@@ -0,0 +1,62 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+)
+
+// Registry holds the configured providers by name.
+type Registry struct {
+	providers map[string]*Provider
+}
+
+// NewRegistry creates a Registry of the given providers.
+func NewRegistry(client *http.Client, configs ...ProviderConfig) (*Registry, error) {
+	r := &Registry{providers: make(map[string]*Provider, len(configs))}
+	for _, cfg := range configs {
+		if cfg.Name == "" || cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc: provider %q needs name, issuer, client_id and redirect_url", cfg.Name)
+		}
+		if _, exists := r.providers[cfg.Name]; exists {
+			return nil, fmt.Errorf("oidc: duplicate provider %q", cfg.Name)
+		}
+		r.providers[cfg.Name] = NewProvider(cfg, client)
+	}
+	return r, nil
+}
+
+// LoadRegistry reads a JSON array of ProviderConfig from file. An empty path yields an empty registry.
+func LoadRegistry(file string) (*Registry, error) {
+	if file == "" {
+		return NewRegistry(nil)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: reading providers file: %w", err)
+	}
+	var configs []ProviderConfig
+	if err = json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("oidc: parsing providers file: %w", err)
+	}
+	return NewRegistry(nil, configs...)
+}
+
+// Get returns the provider with the given name.
+func (r *Registry) Get(name string) (*Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Names returns the sorted names of the configured providers.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,174 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BreachedSource reports whether a password appears in a set of leaked passwords.
+type BreachedSource interface {
+	Check(password string) (bool, error)
+}
+
+var (
+	breachedMu sync.RWMutex
+	breached   BreachedSource
+)
+
+// SetBreached installs the source used by IsBreached, nil disables the check.
+func SetBreached(source BreachedSource) {
+	breachedMu.Lock()
+	defer breachedMu.Unlock()
+	breached = source
+}
+
+// IsBreached checks password against the source installed with SetBreached.
+func IsBreached(password string) (bool, error) {
+	breachedMu.RLock()
+	source := breached
+	breachedMu.RUnlock()
+	if source == nil {
+		return false, nil
+	}
+	return source.Check(password)
+}
+
+// LoadBreached opens path as a BreachedRanges directory when it is one, and as a BreachedList file
+// otherwise.
+func LoadBreached(path string) (BreachedSource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return NewBreachedRanges(path)
+	}
+	return LoadBreachedList(path)
+}
+
+// BreachedList is an offline list of SHA-1 hashes of leaked passwords. Entries may be full hashes or
+// prefixes (at least 5 hex characters) to keep the file small at the cost of false positives.
+type BreachedList struct {
+	entries map[string]struct{}
+	lengths []int
+}
+
+// LoadBreachedList reads one hex SHA-1 hash or prefix per line. A ":count" suffix as in the
+// Have I Been Pwned downloads, blank lines and lines starting with # are ignored.
+func LoadBreachedList(path string) (*BreachedList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b := &BreachedList{entries: make(map[string]struct{})}
+	seen := make(map[int]bool)
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		entry, _, _ = strings.Cut(entry, ":")
+		entry = strings.ToUpper(entry)
+
+		if len(entry) < 5 || len(entry) > sha1.Size*2 || strings.Trim(entry, "0123456789ABCDEF") != "" {
+			return nil, fmt.Errorf("password: %s:%d: invalid SHA-1 prefix %q", path, line, entry)
+		}
+
+		b.entries[entry] = struct{}{}
+		if !seen[len(entry)] {
+			seen[len(entry)] = true
+			b.lengths = append(b.lengths, len(entry))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Ints(b.lengths)
+	return b, nil
+}
+
+// Len returns the number of entries.
+func (b *BreachedList) Len() int {
+	return len(b.entries)
+}
+
+// Check implements BreachedSource.
+func (b *BreachedList) Check(password string) (bool, error) {
+	return b.Contains(password), nil
+}
+
+// Contains reports whether the SHA-1 of password matches an entry of the list.
+func (b *BreachedList) Contains(password string) bool {
+	if b == nil {
+		return false
+	}
+
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	for _, l := range b.lengths {
+		if _, ok := b.entries[digest[:l]]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// BreachedRanges looks passwords up in a directory of Have I Been Pwned range files, one file per
+// 5 character SHA-1 prefix (e.g. 21BD1.txt) holding "SUFFIX:count" lines, as written by the
+// PwnedPasswordsDownloader. Only the file of the password's prefix is read, so the full set is never
+// held in memory.
+type BreachedRanges struct {
+	dir string
+}
+
+// NewBreachedRanges returns a BreachedRanges reading from dir, which must be a readable directory.
+func NewBreachedRanges(dir string) (*BreachedRanges, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.ReadDir(1); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return &BreachedRanges{dir: dir}, nil
+}
+
+// Check implements BreachedSource. A missing range file means no password with that prefix leaked.
+func (r *BreachedRanges) Check(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	f, err := os.Open(filepath.Join(r.dir, digest[:5]+".txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		suffix, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(suffix, digest[5:]) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
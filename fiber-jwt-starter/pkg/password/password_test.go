@@ -0,0 +1,103 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testParams keeps argon2id cheap so the tests stay fast.
+func testParams(algorithm string) Params {
+	return Params{
+		Algorithm:         algorithm,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+		BcryptCost:        4,
+	}
+}
+
+func TestHashAndVerify(t *testing.T) {
+	for _, algorithm := range []string{Argon2id, Bcrypt} {
+		m, err := NewManager(testParams(algorithm))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := m.Hash("s3cret-pass")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = m.Verify(hash, "s3cret-pass"); err != nil {
+			t.Errorf("%s: Verify correct password: %v", algorithm, err)
+		}
+		if err = m.Verify(hash, "wrong-pass"); !errors.Is(err, ErrMismatch) {
+			t.Errorf("%s: Verify wrong password = %v, want ErrMismatch", algorithm, err)
+		}
+		if m.NeedsRehash(hash) {
+			t.Errorf("%s: fresh hash should not need a rehash", algorithm)
+		}
+	}
+}
+
+func TestArgon2idEncoding(t *testing.T) {
+	m, _ := NewManager(testParams(Argon2id))
+	hash, _ := m.Hash("s3cret-pass")
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("unexpected encoding %q", hash)
+	}
+	if other, _ := m.Hash("s3cret-pass"); other == hash {
+		t.Error("hashes of the same password should use different salts")
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	bcryptManager, _ := NewManager(testParams(Bcrypt))
+	legacy, _ := bcryptManager.Hash("s3cret-pass")
+
+	argonManager, _ := NewManager(testParams(Argon2id))
+	if err := argonManager.Verify(legacy, "s3cret-pass"); err != nil {
+		t.Fatalf("argon2id manager should still verify bcrypt hashes: %v", err)
+	}
+	if !argonManager.NeedsRehash(legacy) {
+		t.Error("bcrypt hash should be upgraded when argon2id is preferred")
+	}
+
+	weak, _ := argonManager.Hash("s3cret-pass")
+	stronger := testParams(Argon2id)
+	stronger.Argon2Iterations = 2
+	strongerManager, _ := NewManager(stronger)
+	if !strongerManager.NeedsRehash(weak) {
+		t.Error("hash with fewer iterations should be upgraded")
+	}
+
+	costlier := testParams(Bcrypt)
+	costlier.BcryptCost = 5
+	costlierManager, _ := NewManager(costlier)
+	if !costlierManager.NeedsRehash(legacy) {
+		t.Error("bcrypt hash with lower cost should be upgraded")
+	}
+}
+
+func TestVerifyUnknownFormat(t *testing.T) {
+	m, _ := NewManager(testParams(Argon2id))
+	if err := m.Verify("plaintext", "plaintext"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Verify = %v, want ErrUnknownFormat", err)
+	}
+	if err := m.Verify("$argon2id$v=19$m=1024$broken", "x"); err == nil || errors.Is(err, ErrMismatch) {
+		t.Errorf("malformed hash should fail with a format error, got %v", err)
+	}
+}
+
+func TestNewManagerRejectsInvalidParams(t *testing.T) {
+	p := testParams("scrypt")
+	if _, err := NewManager(p); err == nil {
+		t.Error("unknown algorithm should be rejected")
+	}
+	p = testParams(Argon2id)
+	p.BcryptCost = 99
+	if _, err := NewManager(p); err == nil {
+		t.Error("out of range bcrypt cost should be rejected")
+	}
+}
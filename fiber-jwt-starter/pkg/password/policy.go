@@ -0,0 +1,101 @@
+package password
+
+import (
+	"fiber-jwt-starter/config"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Policy describes the rules a new password must satisfy.
+type Policy struct {
+	MinLength        int // in characters
+	MaxLength        int // in characters, 0 disables
+	RequireUpper     bool
+	RequireLower     bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	BannedSubstrings []string // compared case-insensitively
+}
+
+// DefaultPolicy mirrors the former strong_password rule.
+func DefaultPolicy() Policy {
+	return Policy{MinLength: 12, MaxLength: 128, RequireUpper: true, RequireLower: true, RequireDigit: true}
+}
+
+// PolicyFromConfig builds the policy from the PASSWORD_* settings.
+func PolicyFromConfig() Policy {
+	if config.Envs == nil {
+		return DefaultPolicy()
+	}
+
+	cfg := config.Envs.Password
+	p := Policy{
+		MinLength:     cfg.MinLength,
+		MaxLength:     cfg.MaxLength,
+		RequireUpper:  cfg.RequireUpper,
+		RequireLower:  cfg.RequireLower,
+		RequireDigit:  cfg.RequireDigit,
+		RequireSymbol: cfg.RequireSymbol,
+	}
+	for _, s := range strings.Split(cfg.BannedSubstrings, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			p.BannedSubstrings = append(p.BannedSubstrings, s)
+		}
+	}
+	return p
+}
+
+// Check returns a message for every rule the password violates, nil when it is accepted.
+// personal holds user specific values (e.g. the email local-part) that are banned like BannedSubstrings.
+func (p Policy) Check(password string, personal ...string) []string {
+	var problems []string
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		problems = append(problems, fmt.Sprintf("password minimal %d karakter", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		problems = append(problems, fmt.Sprintf("password maksimal %d karakter", p.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		problems = append(problems, "password harus mengandung huruf besar")
+	}
+	if p.RequireLower && !hasLower {
+		problems = append(problems, "password harus mengandung huruf kecil")
+	}
+	if p.RequireDigit && !hasDigit {
+		problems = append(problems, "password harus mengandung angka")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		problems = append(problems, "password harus mengandung simbol")
+	}
+
+	lower := strings.ToLower(password)
+	for _, banned := range append(p.BannedSubstrings, personal...) {
+		// Very short values such as a one letter email local-part would reject almost everything
+		if utf8.RuneCountInString(banned) < 3 {
+			continue
+		}
+		if strings.Contains(lower, strings.ToLower(banned)) {
+			problems = append(problems, fmt.Sprintf("password tidak boleh mengandung %q", banned))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,142 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	p := DefaultPolicy()
+	p.BannedSubstrings = []string{"Acme"}
+
+	cases := []struct {
+		password string
+		problems int
+	}{
+		{"Correct-Horse-9", 0},
+		{"Short1A", 1},
+		{"alllowercase123", 1},
+		{"ALLUPPERCASE123", 1},
+		{"NoDigitsAtAllHere", 1},
+		{"short", 3},
+		{"Welcome2acme!!", 1},
+		{"JohnDoe-Secret1", 1}, // contains the email local-part
+	}
+
+	for _, tc := range cases {
+		if got := p.Check(tc.password, "johndoe", "x"); len(got) != tc.problems {
+			t.Errorf("Check(%q) = %v, want %d problem(s)", tc.password, got, tc.problems)
+		}
+	}
+}
+
+func TestPolicyMaxLengthAndSymbol(t *testing.T) {
+	p := Policy{MinLength: 4, MaxLength: 8, RequireSymbol: true}
+
+	if got := p.Check("abcd!"); len(got) != 0 {
+		t.Errorf("unexpected problems %v", got)
+	}
+	if got := p.Check("abcdefghij"); len(got) != 2 {
+		t.Errorf("too long password without symbol: %v, want 2 problems", got)
+	}
+}
+
+func TestBreachedList(t *testing.T) {
+	sum := sha1.Sum([]byte("password123"))
+	full := strings.ToUpper(hex.EncodeToString(sum[:]))
+	other := sha1.Sum([]byte("hunter2"))
+	prefix := hex.EncodeToString(other[:])[:8] // lower case prefix is accepted too
+
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	content := "# top leaked passwords\n" + full + ":2254650\n\n" + prefix + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := LoadBreachedList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", b.Len())
+	}
+	if !b.Contains("password123") || !b.Contains("hunter2") {
+		t.Error("listed passwords should be reported as breached")
+	}
+	if b.Contains("Correct-Horse-9") {
+		t.Error("unlisted password reported as breached")
+	}
+
+	var none *BreachedList
+	if none.Contains("password123") {
+		t.Error("nil list should not report anything")
+	}
+}
+
+func TestLoadBreachedListRejectsInvalidLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte("not-a-hash\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBreachedList(path); err == nil {
+		t.Error("invalid line should be rejected")
+	}
+}
+
+func TestBreachedRanges(t *testing.T) {
+	sum := sha1.Sum([]byte("password123"))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	dir := t.TempDir()
+	content := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" + digest[5:] + ":2254650\r\n"
+	if err := os.WriteFile(filepath.Join(dir, digest[:5]+".txt"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := LoadBreached(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := source.(*BreachedRanges); !ok {
+		t.Fatalf("LoadBreached(dir) = %T, want *BreachedRanges", source)
+	}
+	if found, err := source.Check("password123"); err != nil || !found {
+		t.Errorf("Check(listed) = %v, %v, want true", found, err)
+	}
+	// no range file for its prefix
+	if found, err := source.Check("Correct-Horse-9"); err != nil || found {
+		t.Errorf("Check(unlisted) = %v, %v, want false", found, err)
+	}
+}
+
+func TestLoadBreachedFailsOnMissingPath(t *testing.T) {
+	if _, err := LoadBreached(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("missing path should be an error")
+	}
+}
+
+func TestIsBreached(t *testing.T) {
+	defer SetBreached(nil)
+
+	if found, err := IsBreached("password123"); err != nil || found {
+		t.Errorf("IsBreached without a source = %v, %v, want false", found, err)
+	}
+
+	sum := sha1.Sum([]byte("password123"))
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(sum[:])+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source, err := LoadBreached(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetBreached(source)
+	if found, err := IsBreached("password123"); err != nil || !found {
+		t.Errorf("IsBreached(listed) = %v, %v, want true", found, err)
+	}
+}
@@ -0,0 +1,79 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrNoResolver is returned when permissions are checked before SetResolver was called.
+var ErrNoResolver = errors.New("rbac: no resolver configured")
+
+// Grants are the roles of a user and the permissions those roles carry.
+type Grants struct {
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// Has reports whether perm is granted. "*" grants everything and "users:*" grants every users permission.
+func (g Grants) Has(perm string) bool {
+	for _, granted := range g.Permissions {
+		if granted == perm || granted == "*" {
+			return true
+		}
+		if resource, ok := strings.CutSuffix(granted, ":*"); ok && strings.HasPrefix(perm, resource+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole reports whether the role is granted.
+func (g Grants) HasRole(role string) bool {
+	for _, r := range g.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolver loads the grants of a user, typically from the database.
+type Resolver interface {
+	UserGrants(ctx context.Context, userId string) (Grants, error)
+}
+
+var (
+	resolver Resolver
+	mu       sync.RWMutex
+)
+
+// SetResolver replaces the resolver used by UserGrants.
+func SetResolver(r Resolver) {
+	mu.Lock()
+	defer mu.Unlock()
+	resolver = r
+}
+
+// UserGrants resolves the grants of the user with the active resolver.
+func UserGrants(ctx context.Context, userId string) (Grants, error) {
+	mu.RLock()
+	r := resolver
+	mu.RUnlock()
+	if r == nil {
+		return Grants{}, ErrNoResolver
+	}
+	return r.UserGrants(ctx, userId)
+}
+
+// Invalidate drops cached grants of the user, or of every user when userId is empty.
+// It is a no-op when the active resolver does not cache.
+func Invalidate(userId string) {
+	mu.RLock()
+	r := resolver
+	mu.RUnlock()
+	if c, ok := r.(*Cache); ok {
+		c.Invalidate(userId)
+	}
+}
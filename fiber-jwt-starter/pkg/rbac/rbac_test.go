@@ -0,0 +1,76 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGrantsHas(t *testing.T) {
+	g := Grants{Permissions: []string{"users:read", "api_keys:*"}}
+	cases := []struct {
+		perm string
+		want bool
+	}{
+		{"users:read", true},
+		{"users:write", false},
+		{"api_keys:write", true},
+		{"api_keys", false},
+		{"api_keys_extra:write", false},
+	}
+
+	for _, tc := range cases {
+		if got := g.Has(tc.perm); got != tc.want {
+			t.Errorf("Has(%q) = %v, want %v", tc.perm, got, tc.want)
+		}
+	}
+
+	if !(Grants{Permissions: []string{"*"}}).Has("roles:write") {
+		t.Error("wildcard should grant every permission")
+	}
+}
+
+type countingResolver struct {
+	calls int
+}
+
+func (r *countingResolver) UserGrants(ctx context.Context, userId string) (Grants, error) {
+	r.calls++
+	return Grants{Roles: []string{"user"}}, nil
+}
+
+func TestCacheExpiresAndInvalidates(t *testing.T) {
+	inner := &countingResolver{}
+	c := NewCache(inner, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := c.UserGrants(ctx, "u1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Fatalf("expected 1 resolver call, got %d", inner.calls)
+	}
+
+	c.Invalidate("u1")
+	_, _ = c.UserGrants(ctx, "u1")
+	if inner.calls != 2 {
+		t.Fatalf("expected reload after invalidate, got %d calls", inner.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	_, _ = c.UserGrants(ctx, "u1")
+	if inner.calls != 3 {
+		t.Fatalf("expected reload after ttl, got %d calls", inner.calls)
+	}
+}
+
+func TestUserGrantsWithoutResolver(t *testing.T) {
+	SetResolver(nil)
+	if _, err := UserGrants(context.Background(), "u1"); err != ErrNoResolver {
+		t.Fatalf("expected ErrNoResolver, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B test vectors (SHA1, truncated to 6 digits).
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeRFCVectors(t *testing.T) {
+	cases := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tc := range cases {
+		got, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code(%d) error: %v", tc.unix, err)
+		}
+		if got != tc.want {
+			t.Errorf("Code(%d) = %s, want %s", tc.unix, got, tc.want)
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	previous, _ := Code(rfcSecret, Step(now)-1)
+
+	step, ok := Validate(rfcSecret, previous, now, 1)
+	if !ok || step != Step(now)-1 {
+		t.Fatalf("expected previous step to be accepted, got step=%d ok=%v", step, ok)
+	}
+	if _, ok = Validate(rfcSecret, previous, now, 0); ok {
+		t.Fatal("expected previous step to be rejected without skew")
+	}
+	if _, ok = Validate(rfcSecret, "12345", now, 1); ok {
+		t.Fatal("expected short code to be rejected")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("My App", "user@example.com", "JBSWY3DPEHPK3PXP")
+	if !strings.HasPrefix(uri, "otpauth://totp/My%20App:user@example.com?") {
+		t.Fatalf("unexpected uri: %s", uri)
+	}
+	if !strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") || !strings.Contains(uri, "issuer=My+App") {
+		t.Fatalf("missing parameters in uri: %s", uri)
+	}
+}
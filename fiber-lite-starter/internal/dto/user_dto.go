@@ -2,7 +2,15 @@ package dto
 
 type UserRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required,strong_password"`
+}
+
+// UserUpdateRequest replaces email and role of the user (PUT), it is also the document a PATCH merge
+// patch is applied to. The password is only changed when it is sent.
+type UserUpdateRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password,omitempty" validate:"omitempty,strong_password"`
+	Role     string `json:"role" validate:"required,max=50"`
 }
 
 type UserResponse struct {
@@ -10,3 +18,18 @@ type UserResponse struct {
 	Email string `json:"email"`
 	Role  string `json:"role"`
 }
+
+// UserListRequest holds the query parameters of the user listing. created_from and created_to are
+// dates in Asia/Jakarta, sort is a comma separated list of fields, prefixed with "-" for descending.
+// pagination=cursor or a cursor switches from page numbers to keyset pages, newest first.
+type UserListRequest struct {
+	Pagination  string `query:"pagination" validate:"omitempty,oneof=offset cursor"`
+	Cursor      string `query:"cursor" validate:"omitempty,max=512"`
+	Page        int    `query:"page" validate:"omitempty,min=1"`
+	Limit       int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Sort        string `query:"sort" validate:"omitempty,max=100"`
+	Role        string `query:"role" validate:"omitempty,max=50"`
+	Email       string `query:"email" validate:"omitempty,max=100"`
+	CreatedFrom string `query:"created_from" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo   string `query:"created_to" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -1,14 +1,17 @@
 package handler
 
 import (
+	"encoding/json"
 	"fiber-lite-starter/internal/dto"
 	"fiber-lite-starter/internal/service"
 	"fiber-lite-starter/pkg/errmsg"
+	"fiber-lite-starter/pkg/mergepatch"
 	"fiber-lite-starter/pkg/response"
 	"fiber-lite-starter/pkg/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 	"net/http"
+	"strings"
 )
 
 type UserHandler struct {
@@ -45,12 +48,38 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 }
 
 func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
-	results, err := h.Service.Get(c.Context())
+	var req dto.UserListRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::GetUsers - Failed to parse query params")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::GetUsers - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	// Keyset pages for large tables, offset pages (with total) otherwise
+	if req.Pagination == "cursor" || req.Cursor != "" {
+		results, meta, err := h.Service.GetByCursor(c.Context(), req)
+		if err != nil {
+			log.Warn().Err(err).Msg("handler::GetUsers - Service returned error")
+			code, errs := errmsg.Errors(err, &req)
+			return c.Status(code).JSON(response.Error(errs))
+		}
+		return c.Status(http.StatusOK).JSON(response.SuccessWithMeta(results, meta, "Users retrieved successfully"))
+	}
+
+	results, meta, err := h.Service.Get(c.Context(), req)
 	if err != nil {
 		log.Warn().Err(err).Msg("handler::GetUsers - Service returned error")
-		return c.Status(http.StatusInternalServerError).JSON(response.Error("Failed to retrieve users"))
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
 	}
-	return c.Status(http.StatusOK).JSON(response.Success(results, "Users retrieved successfully"))
+	return c.Status(http.StatusOK).JSON(response.SuccessWithMeta(results, meta, "Users retrieved successfully"))
 }
 
 func (h *UserHandler) GetUserById(c *fiber.Ctx) error {
@@ -75,3 +104,119 @@ func (h *UserHandler) GetUserById(c *fiber.Ctx) error {
 
 	return c.Status(http.StatusOK).JSON(response.Success(result, "User retrieved successfully"))
 }
+
+func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !utils.IsValidUUID(id) {
+		log.Warn().Msg("handler::UpdateUser - Invalid User ID format")
+		return c.Status(http.StatusBadRequest).JSON(response.Error("Invalid User ID format"))
+	}
+
+	var req dto.UserUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Info().Err(err).Msg("handler::UpdateUser - Failed to parse request body")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	if err := c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::UpdateUser - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.Update(c.Context(), id, req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::UpdateUser - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "User updated successfully"))
+}
+
+// PatchUser applies a JSON merge patch (RFC 7396) to the user: members that are sent replace the
+// current values and null removes them, which fails validation for required fields. The patched
+// document is validated and stored like a PUT.
+func (h *UserHandler) PatchUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !utils.IsValidUUID(id) {
+		log.Warn().Msg("handler::PatchUser - Invalid User ID format")
+		return c.Status(http.StatusBadRequest).JSON(response.Error("Invalid User ID format"))
+	}
+
+	contentType := c.Get(fiber.HeaderContentType)
+	if contentType != "" && !strings.HasPrefix(contentType, mergepatch.ContentType) && !strings.HasPrefix(contentType, fiber.MIMEApplicationJSON) {
+		log.Info().Str("content_type", contentType).Msg("handler::PatchUser - Unsupported content type")
+		return c.Status(http.StatusUnsupportedMediaType).JSON(response.Error("Content-Type must be " + mergepatch.ContentType))
+	}
+
+	current, err := h.Service.GetById(c.Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::PatchUser - Service returned error")
+		code, errs := errmsg.Errors(err, &id)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	target, _ := json.Marshal(dto.UserUpdateRequest{Email: current.Email, Role: current.Role})
+	merged, err := mergepatch.Apply(target, c.Body())
+	if err != nil {
+		log.Info().Err(err).Msg("handler::PatchUser - Invalid merge patch")
+		return c.Status(http.StatusBadRequest).JSON(response.Error("Invalid merge patch document"))
+	}
+
+	var req dto.UserUpdateRequest
+	if err = json.Unmarshal(merged, &req); err != nil {
+		log.Info().Err(err).Msg("handler::PatchUser - Invalid merge patch")
+		return c.Status(http.StatusBadRequest).JSON(response.Error("Invalid merge patch document"))
+	}
+
+	if err = c.Locals("validator").(func(interface{}) error)(&req); err != nil {
+		log.Info().Err(err).Msg("handler::PatchUser - Validation failed")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	res, err := h.Service.Update(c.Context(), id, req)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::PatchUser - Service returned error")
+		code, errs := errmsg.Errors(err, &req)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "User updated successfully"))
+}
+
+func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !utils.IsValidUUID(id) {
+		log.Warn().Msg("handler::DeleteUser - Invalid User ID format")
+		return c.Status(http.StatusBadRequest).JSON(response.Error("Invalid User ID format"))
+	}
+
+	res, err := h.Service.Delete(c.Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::DeleteUser - Service returned error")
+		code, errs := errmsg.Errors(err, &id)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "User deleted successfully"))
+}
+
+func (h *UserHandler) RestoreUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if !utils.IsValidUUID(id) {
+		log.Warn().Msg("handler::RestoreUser - Invalid User ID format")
+		return c.Status(http.StatusBadRequest).JSON(response.Error("Invalid User ID format"))
+	}
+
+	res, err := h.Service.Restore(c.Context(), id)
+	if err != nil {
+		log.Warn().Err(err).Msg("handler::RestoreUser - Service returned error")
+		code, errs := errmsg.Errors(err, &id)
+		return c.Status(code).JSON(response.Error(errs))
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Success(res, "User restored successfully"))
+}
@@ -16,6 +16,10 @@ func RegisterUserRoutes(router fiber.Router, repo port.RepositoryRegistry) {
 	router.Post("", userHandler.CreateUser)
 	router.Get("", userHandler.GetUsers)
 	router.Get("/:id", userHandler.GetUserById)
+	router.Put("/:id", userHandler.UpdateUser)
+	router.Patch("/:id", userHandler.PatchUser)
+	router.Delete("/:id", userHandler.DeleteUser)
+	router.Post("/:id/restore", userHandler.RestoreUser)
 
 	// Catch-all for unknown routes under /user
 	router.All("/*", func(c *fiber.Ctx) error {
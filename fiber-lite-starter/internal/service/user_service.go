@@ -2,18 +2,28 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"fiber-lite-starter/config"
 	"fiber-lite-starter/internal/dto"
 	"fiber-lite-starter/internal/entity"
 	"fiber-lite-starter/internal/repository/port"
 	"fiber-lite-starter/pkg/errmsg"
+	"fiber-lite-starter/pkg/pagination"
 	"fiber-lite-starter/pkg/utils"
+	"github.com/rs/zerolog/log"
+	"strings"
+	"sync"
+	"time"
 )
 
 type UserService interface {
-	Get(ctx context.Context) ([]dto.UserResponse, error)
+	Get(ctx context.Context, req dto.UserListRequest) ([]dto.UserResponse, pagination.Meta, error)
+	GetByCursor(ctx context.Context, req dto.UserListRequest) ([]dto.UserResponse, pagination.CursorMeta, error)
 	GetById(ctx context.Context, id string) (dto.UserResponse, error)
 	Create(ctx context.Context, req dto.UserRequest) (dto.UserResponse, error)
+	Update(ctx context.Context, id string, req dto.UserUpdateRequest) (dto.UserResponse, error)
+	Delete(ctx context.Context, id string) (dto.UserResponse, error)
+	Restore(ctx context.Context, id string) (dto.UserResponse, error)
 }
 
 type UserServiceImpl struct {
@@ -28,23 +38,125 @@ func NewUserService(repo port.RepositoryRegistry) UserService {
 	}
 }
 
-func (s *UserServiceImpl) Get(ctx context.Context) ([]dto.UserResponse, error) {
+func (s *UserServiceImpl) Get(ctx context.Context, req dto.UserListRequest) ([]dto.UserResponse, pagination.Meta, error) {
+	page, limit := pagination.Page(req.Page, req.Limit)
+	filter, err := userFilter(req)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	filter.Sort = req.Sort
+	filter.Limit = limit
+	filter.Offset = pagination.Offset(page, limit)
+
 	userRepo := s.repository.GetUserRepository()
-	users, err := userRepo.Get(ctx)
+	users, total, err := userRepo.Get(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, pagination.Meta{}, err
+	}
+
+	return toUserResponses(users), pagination.NewMeta(page, limit, total), nil
+}
+
+// GetByCursor returns a keyset page of the users, newest first. Unlike Get it does not count the
+// matching rows and its cost does not grow with the depth of the page.
+func (s *UserServiceImpl) GetByCursor(ctx context.Context, req dto.UserListRequest) ([]dto.UserResponse, pagination.CursorMeta, error) {
+	// Urutan keyset selalu (created_at, id) terbaru dulu
+	if req.Page != 0 || req.Sort != "" {
+		return nil, pagination.CursorMeta{}, errmsg.NewCustomErrors(400, errmsg.WithMessage("page dan sort tidak dapat digunakan bersama cursor"))
+	}
+
+	_, limit := pagination.Page(1, req.Limit)
+	keyset := pagination.Keyset{Limit: limit}
+	if req.Cursor != "" {
+		cursor, err := pagination.DecodeCursor(req.Cursor, s.cursorSecret())
+		if err != nil {
+			return nil, pagination.CursorMeta{}, errmsg.NewCustomErrors(400, errmsg.WithErrors("cursor", "cursor tidak valid."))
+		}
+		keyset.Cursor = &cursor
+	}
+
+	filter, err := userFilter(req)
+	if err != nil {
+		return nil, pagination.CursorMeta{}, err
+	}
+
+	userRepo := s.repository.GetUserRepository()
+	rows, err := userRepo.GetByCursor(ctx, filter, keyset)
+	if err != nil {
+		return nil, pagination.CursorMeta{}, err
+	}
+
+	users, next, prev := pagination.KeysetPage(keyset, rows, func(user *entity.UserDB) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: user.CreatedAt, ID: user.Id}
+	})
+	meta := pagination.CursorMeta{Limit: limit}
+	if next != nil {
+		token := next.Encode(s.cursorSecret())
+		meta.NextCursor = &token
+	}
+	if prev != nil {
+		token := prev.Encode(s.cursorSecret())
+		meta.PrevCursor = &token
+	}
+
+	return toUserResponses(users), meta, nil
+}
+
+// cursorSecret signs the cursor tokens, PAGINATION_CURSOR_SECRET or a random per-process secret when it is not set.
+func (s *UserServiceImpl) cursorSecret() []byte {
+	if secret := s.cfg.Pagination.CursorSecret; secret != "" {
+		return []byte(secret)
+	}
+	return processCursorSecret()
+}
+
+// processCursorSecret is generated once per process, its cursors stop working after a restart and
+// are rejected by other instances.
+var processCursorSecret = sync.OnceValue(func() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic("service::cursorSecret - failed to generate cursor secret: " + err.Error())
 	}
+	log.Warn().Msg("service::cursorSecret - PAGINATION_CURSOR_SECRET is not set, cursors are signed with a random per-process secret")
+	return secret
+})
 
-	var responses []dto.UserResponse
+// userFilter builds the filter shared by offset and keyset pages.
+func userFilter(req dto.UserListRequest) (entity.UserFilter, error) {
+	filter := entity.UserFilter{
+		Role:  req.Role,
+		Email: strings.TrimSpace(req.Email),
+	}
+
+	// Rentang tanggal dalam zona Jakarta, dikonversi ke UTC seperti created_at
+	if req.CreatedFrom != "" && req.CreatedTo != "" && req.CreatedFrom > req.CreatedTo {
+		return entity.UserFilter{}, errmsg.NewCustomErrors(400, errmsg.WithErrors("created_to", "created to harus sama dengan atau setelah created from."))
+	}
+	if req.CreatedFrom != "" {
+		filter.CreatedFrom, _ = utils.ConvertDateJKTToUTCRange(req.CreatedFrom, req.CreatedFrom)
+	}
+	if req.CreatedTo != "" {
+		// batas atas eksklusif: awal hari berikutnya, agar created_at setelah 23:59:59 (pecahan detik) tetap ikut
+		nextDay, _ := time.ParseInLocation(utils.DateFormat, req.CreatedTo, utils.LocJkt())
+		filter.CreatedBefore = nextDay.AddDate(0, 0, 1).UTC().Format(utils.DateTimeFormat)
+	}
+	return filter, nil
+}
+
+func toUserResponses(users []*entity.UserDB) []dto.UserResponse {
+	responses := make([]dto.UserResponse, 0, len(users))
 	for _, user := range users {
-		responses = append(responses, dto.UserResponse{
-			Id:    user.Id,
-			Email: user.Email,
-			Role:  user.Role,
-		})
+		responses = append(responses, toUserResponse(user))
 	}
+	return responses
+}
 
-	return responses, nil
+func toUserResponse(user *entity.UserDB) dto.UserResponse {
+	return dto.UserResponse{
+		Id:    user.Id,
+		Email: user.Email,
+		Role:  user.Role,
+	}
 }
 
 func (s *UserServiceImpl) GetById(ctx context.Context, id string) (dto.UserResponse, error) {
@@ -54,11 +166,7 @@ func (s *UserServiceImpl) GetById(ctx context.Context, id string) (dto.UserRespo
 		return dto.UserResponse{}, err
 	}
 
-	return dto.UserResponse{
-		Id:    user.Id,
-		Email: user.Email,
-		Role:  user.Role,
-	}, nil
+	return toUserResponse(user), nil
 }
 
 func (s *UserServiceImpl) Create(ctx context.Context, req dto.UserRequest) (dto.UserResponse, error) {
@@ -91,9 +199,68 @@ func (s *UserServiceImpl) Create(ctx context.Context, req dto.UserRequest) (dto.
 		return dto.UserResponse{}, err
 	}
 
-	return dto.UserResponse{
-		Id:    user.Id,
-		Email: user.Email,
-		Role:  user.Role,
-	}, nil
+	return toUserResponse(user), nil
+}
+
+func (s *UserServiceImpl) Update(ctx context.Context, id string, req dto.UserUpdateRequest) (dto.UserResponse, error) {
+	userRepo := s.repository.GetUserRepository()
+	user, err := userRepo.GetById(ctx, id)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	// Cek email baru belum dipakai user lain
+	if req.Email != user.Email {
+		existing, err := userRepo.ExistsByEmail(ctx, req.Email)
+		if err != nil {
+			return dto.UserResponse{}, err
+		}
+		if existing {
+			return dto.UserResponse{}, errmsg.NewCustomErrors(409, errmsg.WithErrors("email", "Email sudah terdaftar"))
+		}
+	}
+
+	// Password hanya diganti bila dikirim
+	if req.Password != "" {
+		if user.Password, err = utils.HashPassword(req.Password); err != nil {
+			return dto.UserResponse{}, errmsg.NewCustomErrors(500, errmsg.WithMessage("Gagal mengenkripsi password"))
+		}
+	}
+	user.Email = req.Email
+	user.Role = req.Role
+
+	if err = userRepo.Update(ctx, user); err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+// Delete soft deletes the user (deleted_at) and returns it as it was before the deletion.
+func (s *UserServiceImpl) Delete(ctx context.Context, id string) (dto.UserResponse, error) {
+	userRepo := s.repository.GetUserRepository()
+	user, err := userRepo.GetById(ctx, id)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	if err = userRepo.SoftDelete(ctx, id); err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (s *UserServiceImpl) Restore(ctx context.Context, id string) (dto.UserResponse, error) {
+	userRepo := s.repository.GetUserRepository()
+	if err := userRepo.Restore(ctx, id); err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	user, err := userRepo.GetById(ctx, id)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	return toUserResponse(user), nil
 }
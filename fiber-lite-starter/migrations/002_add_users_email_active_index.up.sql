@@ -0,0 +1,3 @@
+-- soft deleted users keep their row, their email can be used again
+ALTER TABLE public.users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_active_key ON public.users (email) WHERE deleted_at IS NULL;
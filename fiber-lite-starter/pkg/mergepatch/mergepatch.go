@@ -0,0 +1,51 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ContentType is the media type of JSON merge patch documents.
+const ContentType = "application/merge-patch+json"
+
+// ErrInvalidPatch is returned when the patch is not valid JSON.
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// Apply applies the JSON merge patch (RFC 7396) to the target document: members of a patch object
+// replace those of the target, null members remove them, and a patch that is not an object
+// replaces the whole target.
+func Apply(target, patch []byte) ([]byte, error) {
+	var patchDoc any
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	var targetDoc any
+	if len(target) > 0 {
+		if err := json.Unmarshal(target, &targetDoc); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(merge(targetDoc, patchDoc))
+}
+
+func merge(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any)
+	}
+	for name, value := range patchObj {
+		if value == nil {
+			delete(targetObj, name)
+			continue
+		}
+		targetObj[name] = merge(targetObj[name], value)
+	}
+	return targetObj
+}
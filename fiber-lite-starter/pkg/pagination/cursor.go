@@ -0,0 +1,136 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned for cursor tokens that are malformed or not signed with the secret.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// timestampFormat keeps the microsecond precision of Postgres TIMESTAMP columns, the value is
+// compared as a plain timestamp so the session time zone does not shift it.
+const timestampFormat = "2006-01-02 15:04:05.999999"
+
+// Cursor is the keyset position of a row in a list ordered newest first by (created_at, id).
+// A Prev cursor asks for the page before the row instead of the page after it.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+	Prev      bool      `json:"p,omitempty"`
+}
+
+// CursorMeta is the pagination block of a keyset page, the cursors are nil at either end of the list.
+type CursorMeta struct {
+	Limit      int     `json:"limit"`
+	NextCursor *string `json:"next_cursor"`
+	PrevCursor *string `json:"prev_cursor"`
+}
+
+// Encode returns the opaque token of the cursor: its JSON and an HMAC-SHA256 signature, both base64url
+// encoded, so clients cannot forge positions.
+func (c Cursor) Encode(secret []byte) string {
+	c.CreatedAt = c.CreatedAt.UTC()
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload, secret))
+}
+
+// DecodeCursor verifies the signature of token and returns its cursor.
+func DecodeCursor(token string, secret []byte) (Cursor, error) {
+	encodedPayload, encodedSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, sign(payload, secret)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err = json.Unmarshal(payload, &c); err != nil || c.ID == "" || c.CreatedAt.IsZero() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func sign(payload, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Keyset is a keyset page request over the newest first (created_at, id) order. Repositories add
+// Condition to their WHERE clause, order by OrderBy and fetch FetchLimit rows, then KeysetPage
+// turns the rows into the page and its cursors.
+type Keyset struct {
+	Cursor *Cursor // nil for the first page
+	Limit  int
+}
+
+// Condition returns the condition selecting the rows past the cursor, with placeholders numbered
+// from argIndex, and its arguments. It is empty for the first page.
+func (k Keyset) Condition(createdAtColumn, idColumn string, argIndex int) (string, []any) {
+	if k.Cursor == nil {
+		return "", nil
+	}
+	op := "<"
+	if k.Cursor.Prev {
+		op = ">"
+	}
+	condition := fmt.Sprintf("(%s, %s) %s ($%d, $%d)", createdAtColumn, idColumn, op, argIndex, argIndex+1)
+	return condition, []any{k.Cursor.CreatedAt.UTC().Format(timestampFormat), k.Cursor.ID}
+}
+
+// OrderBy returns the ORDER BY list. Pages before a Prev cursor are read oldest first and put back
+// in order by KeysetPage.
+func (k Keyset) OrderBy(createdAtColumn, idColumn string) string {
+	dir := direction(k.Cursor == nil || !k.Cursor.Prev)
+	return createdAtColumn + dir + ", " + idColumn + dir
+}
+
+// FetchLimit is one more than Limit, the extra row tells whether the list continues.
+func (k Keyset) FetchLimit() int {
+	return k.Limit + 1
+}
+
+// KeysetPage trims the rows read for k to the page, newest first, and returns the cursors of the
+// pages after and before it. position returns the (created_at, id) of a row.
+func KeysetPage[T any](k Keyset, rows []T, position func(T) Cursor) ([]T, *Cursor, *Cursor) {
+	more := len(rows) > k.Limit
+	if more {
+		rows = rows[:k.Limit]
+	}
+	backward := k.Cursor != nil && k.Cursor.Prev
+	if backward {
+		slices.Reverse(rows)
+	}
+	if len(rows) == 0 {
+		return rows, nil, nil
+	}
+
+	var next, prev *Cursor
+	// going forward the list continues after the page when more rows were read, and there is a
+	// page before it once a cursor was used; going backward it is the other way around
+	if backward || more {
+		c := position(rows[len(rows)-1])
+		c.Prev = false
+		next = &c
+	}
+	if (backward && more) || (!backward && k.Cursor != nil) {
+		c := position(rows[0])
+		c.Prev = true
+		prev = &c
+	}
+	return rows, next, prev
+}
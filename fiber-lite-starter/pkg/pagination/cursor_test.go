@@ -0,0 +1,84 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("cursor-secret")
+
+type row struct {
+	createdAt time.Time
+	id        string
+}
+
+func position(r row) Cursor {
+	return Cursor{CreatedAt: r.createdAt, ID: r.id}
+}
+
+func TestCursorEncodeDecode(t *testing.T) {
+	c := Cursor{CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 123456000, time.UTC), ID: "a", Prev: true}
+
+	got, err := DecodeCursor(c.Encode(secret), secret)
+	require.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, c.ID, got.ID)
+	assert.True(t, got.Prev)
+
+	_, err = DecodeCursor(c.Encode([]byte("other")), secret)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = DecodeCursor("garbage", secret)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestKeysetCondition(t *testing.T) {
+	condition, args := Keyset{Limit: 10}.Condition("u.created_at", "u.id", 1)
+	assert.Empty(t, condition)
+	assert.Empty(t, args)
+
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	k := Keyset{Cursor: &Cursor{CreatedAt: at, ID: "a"}, Limit: 10}
+	condition, args = k.Condition("u.created_at", "u.id", 3)
+	assert.Equal(t, "(u.created_at, u.id) < ($3, $4)", condition)
+	assert.Equal(t, []any{"2025-01-02 03:04:05", "a"}, args)
+	assert.Equal(t, "u.created_at DESC, u.id DESC", k.OrderBy("u.created_at", "u.id"))
+
+	k.Cursor.Prev = true
+	condition, _ = k.Condition("u.created_at", "u.id", 1)
+	assert.Equal(t, "(u.created_at, u.id) > ($1, $2)", condition)
+	assert.Equal(t, "u.created_at ASC, u.id ASC", k.OrderBy("u.created_at", "u.id"))
+}
+
+func TestKeysetPage(t *testing.T) {
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []row{{at.Add(3), "c"}, {at.Add(2), "b"}, {at.Add(1), "a"}}
+
+	// first page with more rows left
+	page, next, prev := KeysetPage(Keyset{Limit: 2}, rows, position)
+	assert.Equal(t, rows[:2], page)
+	require.NotNil(t, next)
+	assert.Equal(t, "b", next.ID)
+	assert.Nil(t, prev)
+
+	// last page reached with a next cursor
+	page, next, prev = KeysetPage(Keyset{Cursor: &Cursor{ID: "d"}, Limit: 5}, rows, position)
+	assert.Len(t, page, 3)
+	assert.Nil(t, next)
+	require.NotNil(t, prev)
+	assert.Equal(t, "c", prev.ID)
+	assert.True(t, prev.Prev)
+
+	// prev page rows are read oldest first and reversed
+	ascending := []row{rows[2], rows[1], rows[0]}
+	page, next, prev = KeysetPage(Keyset{Cursor: &Cursor{ID: "z", Prev: true}, Limit: 2}, ascending, position)
+	assert.Equal(t, []row{rows[1], rows[2]}, page)
+	require.NotNil(t, next)
+	assert.Equal(t, "a", next.ID)
+	assert.False(t, next.Prev)
+	require.NotNil(t, prev)
+	assert.Equal(t, "b", prev.ID)
+}
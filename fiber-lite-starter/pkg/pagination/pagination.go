@@ -0,0 +1,94 @@
+package pagination
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Meta is the pagination block returned next to a list, see response.SuccessWithMeta.
+type Meta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// Page normalizes the requested page and limit: page starts at 1, limit defaults to DefaultLimit
+// and is capped at MaxLimit.
+func Page(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+	return page, min(limit, MaxLimit)
+}
+
+// Offset returns the number of rows skipped before the given page.
+func Offset(page, limit int) int {
+	return (page - 1) * limit
+}
+
+func NewMeta(page, limit, total int) Meta {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = (total + limit - 1) / limit
+	}
+	return Meta{Page: page, Limit: limit, Total: total, TotalPages: totalPages}
+}
+
+// Sort is one field of a sort parameter, "-created_at" sorts created_at descending.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a comma separated sort parameter such as "-created_at,email". Only the keys of
+// columns are accepted, so user input never reaches the SQL.
+func ParseSort(raw string, columns map[string]string) ([]Sort, error) {
+	var sorts []Sort
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		sort := Sort{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if _, ok := columns[sort.Field]; !ok {
+			return nil, fmt.Errorf("cannot sort by %q", sort.Field)
+		}
+		if seen[sort.Field] {
+			continue
+		}
+		seen[sort.Field] = true
+		sorts = append(sorts, sort)
+	}
+	return sorts, nil
+}
+
+// OrderBy builds the ORDER BY list of sorts with the column names of columns. tieBreaker (a unique
+// column such as the primary key) is appended so rows with equal values keep a stable order across pages.
+func OrderBy(sorts []Sort, columns map[string]string, tieBreaker string) string {
+	parts := make([]string, 0, len(sorts)+1)
+	for _, sort := range sorts {
+		parts = append(parts, columns[sort.Field]+direction(sort.Desc))
+	}
+
+	desc := len(sorts) > 0 && sorts[len(sorts)-1].Desc
+	parts = append(parts, tieBreaker+direction(desc))
+	return strings.Join(parts, ", ")
+}
+
+func direction(desc bool) string {
+	if desc {
+		return " DESC"
+	}
+	return " ASC"
+}